@@ -23,6 +23,7 @@ type BuildCmd struct {
 
 	SkipPush                bool
 	AllowCyclicDependencies bool
+	FrozenLock              bool
 	VerboseDependencies     bool
 
 	ForceBuild        bool
@@ -47,6 +48,7 @@ Builds all defined images and pushes them
 	}
 
 	buildCmd.Flags().BoolVar(&cmd.AllowCyclicDependencies, "allow-cyclic", false, "When enabled allows cyclic dependencies")
+	buildCmd.Flags().BoolVar(&cmd.FrozenLock, "frozen", false, "Fails if devspace.lock is missing or out of date instead of updating it")
 
 	buildCmd.Flags().BoolVarP(&cmd.ForceBuild, "force-build", "b", false, "Forces to build every image")
 	buildCmd.Flags().BoolVar(&cmd.BuildSequential, "build-sequential", false, "Builds the images one after another instead of in parallel")
@@ -94,7 +96,7 @@ func (cmd *BuildCmd) Run(cobraCmd *cobra.Command, args []string) error {
 	}
 
 	// Dependencies
-	err = dependency.BuildAll(config, generatedConfig, cmd.AllowCyclicDependencies, false, cmd.SkipPush, cmd.ForceDependencies, cmd.ForceBuild, cmd.VerboseDependencies, configOptions, log.GetInstance())
+	err = dependency.BuildAll(config, generatedConfig, cmd.AllowCyclicDependencies, cmd.FrozenLock, false, cmd.SkipPush, cmd.ForceDependencies, cmd.ForceBuild, cmd.VerboseDependencies, configOptions, log.GetInstance())
 	if err != nil {
 		return errors.Wrap(err, "build dependencies")
 	}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"os"
 	"strconv"
 	"strings"
 
@@ -9,11 +10,13 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/dependency"
 	deploy "github.com/devspace-cloud/devspace/pkg/devspace/deploy/util"
 	"github.com/devspace-cloud/devspace/pkg/devspace/docker"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
 	"github.com/devspace-cloud/devspace/pkg/devspace/registry"
+	"github.com/devspace-cloud/devspace/pkg/util/exit"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/mgutz/ansi"
 	"github.com/pkg/errors"
@@ -25,16 +28,20 @@ import (
 type DeployCmd struct {
 	*flags.GlobalFlags
 
-	ForceBuild          bool
-	SkipBuild           bool
-	BuildSequential     bool
-	ForceDeploy         bool
-	Deployments         string
-	ForceDependencies   bool
-	VerboseDependencies bool
+	ForceBuild                bool
+	SkipBuild                 bool
+	BuildSequential           bool
+	ForceDeploy               bool
+	Deployments               string
+	ForceDependencies         bool
+	VerboseDependencies       bool
+	MaxConcurrentDependencies int
 
 	SkipPush                bool
 	AllowCyclicDependencies bool
+	FrozenLock              bool
+
+	DryRun bool
 }
 
 // NewDeployCmd creates a new deploy command
@@ -53,13 +60,16 @@ Deploys the current project to a Space or namespace:
 devspace deploy
 devspace deploy -n deploy
 devspace deploy --kube-context=deploy-context
+devspace deploy --dry-run
 #######################################################`,
 		Args: cobra.NoArgs,
 		RunE: cmd.Run,
 	}
 
 	deployCmd.Flags().BoolVar(&cmd.AllowCyclicDependencies, "allow-cyclic", false, "When enabled allows cyclic dependencies")
+	deployCmd.Flags().BoolVar(&cmd.FrozenLock, "frozen", false, "Fails if devspace.lock is missing or out of date instead of updating it")
 	deployCmd.Flags().BoolVar(&cmd.VerboseDependencies, "verbose-dependencies", false, "Deploys the dependencies verbosely")
+	deployCmd.Flags().IntVar(&cmd.MaxConcurrentDependencies, "max-concurrent-dependencies", dependency.DefaultMaxConcurrency, "Maximum number of dependencies that are deployed in parallel (1 deploys them one after another)")
 
 	deployCmd.Flags().BoolVar(&cmd.SkipPush, "skip-push", false, "Skips image pushing, useful for minikube deployment")
 
@@ -69,6 +79,7 @@ devspace deploy --kube-context=deploy-context
 	deployCmd.Flags().BoolVarP(&cmd.ForceDeploy, "force-deploy", "d", false, "Forces to (re-)deploy every deployment")
 	deployCmd.Flags().BoolVar(&cmd.ForceDependencies, "force-dependencies", false, "Forces to re-evaluate dependencies (use with --force-build --force-deploy to actually force building & deployment of dependencies)")
 	deployCmd.Flags().StringVar(&cmd.Deployments, "deployments", "", "Only deploy a specifc deployment (You can specify multiple deployments comma-separated")
+	deployCmd.Flags().BoolVar(&cmd.DryRun, "dry-run", false, "Only prints the changes a deployment would make to the cluster and exits with code 1 if there are any")
 
 	return deployCmd
 }
@@ -136,6 +147,11 @@ func (cmd *DeployCmd) Run(cobraCmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Only print the changes without building or deploying anything
+	if cmd.DryRun {
+		return cmd.diff(config, generatedConfig, client)
+	}
+
 	// Create namespace if necessary
 	err = client.EnsureDefaultNamespace(log.GetInstance())
 	if err != nil {
@@ -155,7 +171,7 @@ func (cmd *DeployCmd) Run(cobraCmd *cobra.Command, args []string) error {
 	}
 
 	// Dependencies
-	err = dependency.DeployAll(config, generatedConfig, client, cmd.AllowCyclicDependencies, false, cmd.SkipPush, cmd.ForceDependencies, cmd.SkipBuild, cmd.ForceBuild, cmd.ForceDeploy, cmd.VerboseDependencies, configOptions, log.GetInstance())
+	_, err = dependency.DeployAll(config, generatedConfig, client, cmd.AllowCyclicDependencies, cmd.FrozenLock, false, cmd.SkipPush, cmd.ForceDependencies, cmd.SkipBuild, cmd.ForceBuild, cmd.ForceDeploy, cmd.VerboseDependencies, cmd.MaxConcurrentDependencies, configOptions, log.GetInstance())
 	if err != nil {
 		return errors.Wrap(err, "deploy dependencies")
 	}
@@ -181,17 +197,8 @@ func (cmd *DeployCmd) Run(cobraCmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// What deployments should be deployed
-	deployments := []string{}
-	if cmd.Deployments != "" {
-		deployments = strings.Split(cmd.Deployments, ",")
-		for index := range deployments {
-			deployments[index] = strings.TrimSpace(deployments[index])
-		}
-	}
-
 	// Deploy all defined deployments
-	err = deploy.All(config, generatedConfig.GetActive(), client, false, cmd.ForceDeploy, builtImages, deployments, log.GetInstance())
+	err = deploy.All(config, generatedConfig.GetActive(), client, false, cmd.ForceDeploy, builtImages, cmd.getDeployments(), log.GetInstance())
 	if err != nil {
 		return err
 	}
@@ -207,10 +214,44 @@ func (cmd *DeployCmd) Run(cobraCmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// diff compares the rendered deployments with the cluster and returns a non zero exit code if anything would change
+func (cmd *DeployCmd) diff(config *latest.Config, generatedConfig *generated.Config, client *kubectl.Client) error {
+	changed, err := deploy.Diff(config, generatedConfig.GetActive(), client, cmd.getDeployments(), os.Stdout, log.GetInstance())
+	if err != nil {
+		return err
+	}
+
+	if changed > 0 {
+		log.Warnf("%d object(s) would be created or changed by `%s`", changed, ansi.Color("devspace deploy", "white+b"))
+		return &exit.ReturnCodeError{
+			ExitCode: 1,
+		}
+	}
+
+	log.Done("Cluster is up to date, nothing would be changed")
+	return nil
+}
+
+// getDeployments returns the deployments that should be deployed
+func (cmd *DeployCmd) getDeployments() []string {
+	deployments := []string{}
+	if cmd.Deployments != "" {
+		deployments = strings.Split(cmd.Deployments, ",")
+		for index := range deployments {
+			deployments[index] = strings.TrimSpace(deployments[index])
+		}
+	}
+
+	return deployments
+}
+
 func (cmd *DeployCmd) validateFlags() error {
 	if cmd.SkipBuild && cmd.ForceBuild {
 		return errors.New("Flags --skip-build & --force-build cannot be used together")
 	}
+	if cmd.DryRun && (cmd.ForceBuild || cmd.ForceDeploy) {
+		return errors.New("Flag --dry-run cannot be used together with --force-build or --force-deploy")
+	}
 
 	return nil
 }
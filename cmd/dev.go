@@ -9,6 +9,7 @@ import (
 	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/build"
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
+	"github.com/devspace-cloud/devspace/pkg/devspace/debugger"
 	"github.com/devspace-cloud/devspace/pkg/devspace/dependency"
 	deploy "github.com/devspace-cloud/devspace/pkg/devspace/deploy/util"
 	"github.com/devspace-cloud/devspace/pkg/devspace/server"
@@ -17,6 +18,7 @@ import (
 	"github.com/mgutz/ansi"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	latest "github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/docker"
@@ -36,10 +38,12 @@ import (
 type DevCmd struct {
 	*flags.GlobalFlags
 
-	SkipPush                bool
-	AllowCyclicDependencies bool
-	VerboseDependencies     bool
-	Open                    bool
+	SkipPush                  bool
+	AllowCyclicDependencies   bool
+	FrozenLock                bool
+	VerboseDependencies       bool
+	MaxConcurrentDependencies int
+	Open                      bool
 
 	ForceBuild        bool
 	SkipBuild         bool
@@ -88,7 +92,9 @@ Open terminal instead of logs:
 	}
 
 	devCmd.Flags().BoolVar(&cmd.AllowCyclicDependencies, "allow-cyclic", false, "When enabled allows cyclic dependencies")
+	devCmd.Flags().BoolVar(&cmd.FrozenLock, "frozen", false, "Fails if devspace.lock is missing or out of date instead of updating it")
 	devCmd.Flags().BoolVar(&cmd.VerboseDependencies, "verbose-dependencies", false, "Deploys the dependencies verbosely")
+	devCmd.Flags().IntVar(&cmd.MaxConcurrentDependencies, "max-concurrent-dependencies", dependency.DefaultMaxConcurrency, "Maximum number of dependencies that are deployed in parallel (1 deploys them one after another)")
 
 	devCmd.Flags().BoolVarP(&cmd.ForceBuild, "force-build", "b", false, "Forces to build every image")
 	devCmd.Flags().BoolVar(&cmd.SkipBuild, "skip-build", false, "Skips building of images")
@@ -128,6 +134,13 @@ func (cmd *DevCmd) Run(cobraCmd *cobra.Command, args []string) error {
 	// Start file logging
 	log.StartFileLogging()
 
+	// Record build output, deploy results, sync changes and container logs of this session
+	_, err = log.StartSessionRecording()
+	if err != nil {
+		log.Warnf("Couldn't start session recording: %v", err)
+	}
+	defer log.StopSessionRecording()
+
 	// Validate flags
 	err = cmd.validateFlags()
 	if err != nil {
@@ -207,16 +220,22 @@ func (cmd *DevCmd) Run(cobraCmd *cobra.Command, args []string) error {
 }
 
 func (cmd *DevCmd) buildAndDeploy(config *latest.Config, generatedConfig *generated.Config, client *kubectl.Client, args []string, skipBuildIfAlreadyBuilt bool) (int, error) {
+	var devDependencies []*dependency.Dependency
 	if cmd.SkipPipeline == false {
 		// Dependencies
-		err := dependency.DeployAll(config, generatedConfig, client, cmd.AllowCyclicDependencies, false, cmd.SkipPush, cmd.ForceDependencies, cmd.SkipBuild, cmd.ForceBuild, cmd.ForceDeploy, cmd.VerboseDependencies, cmd.ToConfigOptions(), log.GetInstance())
+		log.SetSessionSource(log.SourceDeploy)
+		dependencies, err := dependency.DeployAll(config, generatedConfig, client, cmd.AllowCyclicDependencies, cmd.FrozenLock, false, cmd.SkipPush, cmd.ForceDependencies, cmd.SkipBuild, cmd.ForceBuild, cmd.ForceDeploy, cmd.VerboseDependencies, cmd.MaxConcurrentDependencies, cmd.ToConfigOptions(), log.GetInstance())
 		if err != nil {
 			return 0, errors.Errorf("Error deploying dependencies: %v", err)
 		}
 
+		// Reuse the resolved dependencies for the dev services
+		devDependencies = dependency.GetDevDependencies(dependencies)
+
 		// Build image if necessary
 		builtImages := make(map[string]string)
 		if cmd.SkipBuild == false {
+			log.SetSessionSource(log.SourceBuild)
 			builtImages, err = build.All(config, generatedConfig.GetActive(), client, cmd.SkipPush, true, cmd.ForceBuild, cmd.BuildSequential, skipBuildIfAlreadyBuilt, log.GetInstance())
 			if err != nil {
 				if strings.Index(err.Error(), "no space left on device") != -1 {
@@ -247,6 +266,7 @@ func (cmd *DevCmd) buildAndDeploy(config *latest.Config, generatedConfig *genera
 			}
 
 			// Deploy all
+			log.SetSessionSource(log.SourceDeploy)
 			err = deploy.All(config, generatedConfig.GetActive(), client, true, cmd.ForceDeploy, builtImages, deployments, log.GetInstance())
 			if err != nil {
 				return 0, errors.Errorf("Error deploying: %v", err)
@@ -259,11 +279,20 @@ func (cmd *DevCmd) buildAndDeploy(config *latest.Config, generatedConfig *genera
 			}
 		}
 
+		log.SetSessionSource(log.SourceDevSpace)
+
 		// Update last used kube context
 		err = client.UpdateLastKubeContext(generatedConfig)
 		if err != nil {
 			return 0, errors.Wrap(err, "update last kube context")
 		}
+	} else {
+		// Resolve the dependencies that have dev services enabled
+		var err error
+		devDependencies, err = dependency.ResolveDev(config, generatedConfig, cmd.AllowCyclicDependencies, cmd.FrozenLock, cmd.ToConfigOptions(), logutil.Discard)
+		if err != nil {
+			return 0, errors.Wrap(err, "resolve dependencies")
+		}
 	}
 
 	// Start services
@@ -272,7 +301,7 @@ func (cmd *DevCmd) buildAndDeploy(config *latest.Config, generatedConfig *genera
 		var err error
 
 		// Start services
-		exitCode, err = cmd.startServices(config, generatedConfig, client, args, log.GetInstance())
+		exitCode, err = cmd.startServices(config, generatedConfig, client, devDependencies, args, log.GetInstance())
 		if err != nil {
 			// Check if we should reload
 			if _, ok := err.(*reloadError); ok {
@@ -293,13 +322,39 @@ func (cmd *DevCmd) buildAndDeploy(config *latest.Config, generatedConfig *genera
 	return exitCode, nil
 }
 
-func (cmd *DevCmd) startServices(config *latest.Config, generatedConfig *generated.Config, client *kubectl.Client, args []string, log log.Logger) (int, error) {
+func (cmd *DevCmd) startServices(config *latest.Config, generatedConfig *generated.Config, client *kubectl.Client, devDependencies []*dependency.Dependency, args []string, log log.Logger) (int, error) {
 	if cmd.Portforwarding {
+		// Check for port conflicts before starting any port forwarding
+		names := []string{constants.DefaultConfigPath}
+		configs := []*latest.Config{config}
+		for _, devDependency := range devDependencies {
+			names = append(names, "dependency "+devDependency.ID)
+			configs = append(configs, devDependency.Config)
+		}
+
+		err := services.CheckPortConflicts(names, configs)
+		if err != nil {
+			return 0, errors.Errorf("Unable to start portforwarding: %v", err)
+		}
+
 		portForwarder, err := services.StartPortForwarding(config, generatedConfig, client, log)
 		if err != nil {
 			return 0, errors.Errorf("Unable to start portforwarding: %v", err)
 		}
 
+		for _, devDependency := range devDependencies {
+			dependencyPortForwarder, err := services.StartPortForwarding(devDependency.Config, devDependency.GeneratedConfig, client, log)
+			if err != nil {
+				for _, v := range portForwarder {
+					v.Close()
+				}
+
+				return 0, errors.Errorf("Unable to start portforwarding for dependency %s: %v", devDependency.ID, err)
+			}
+
+			portForwarder = append(portForwarder, dependencyPortForwarder...)
+		}
+
 		defer func() {
 			for _, v := range portForwarder {
 				v.Close()
@@ -313,6 +368,19 @@ func (cmd *DevCmd) startServices(config *latest.Config, generatedConfig *generat
 			return 0, errors.Errorf("Unable to start sync: %v", err)
 		}
 
+		for _, devDependency := range devDependencies {
+			dependencySyncConfigs, err := services.StartSync(devDependency.Config, devDependency.GeneratedConfig, client, cmd.VerboseSync, log)
+			if err != nil {
+				for _, v := range syncConfigs {
+					v.Stop(nil)
+				}
+
+				return 0, errors.Errorf("Unable to start sync for dependency %s: %v", devDependency.ID, err)
+			}
+
+			syncConfigs = append(syncConfigs, dependencySyncConfigs...)
+		}
+
 		defer func() {
 			for _, v := range syncConfigs {
 				v.Stop(nil)
@@ -320,6 +388,11 @@ func (cmd *DevCmd) startServices(config *latest.Config, generatedConfig *generat
 		}()
 	}
 
+	// Start the persistent terminal sessions
+	if len(config.Dev.Terminals) > 0 {
+		services.StartSessions(config, generatedConfig, client, log)
+	}
+
 	var (
 		exitChan        = make(chan error)
 		autoReloadPaths = GetPaths(config)
@@ -430,42 +503,27 @@ func (cmd *DevCmd) startServices(config *latest.Config, generatedConfig *generat
 
 		return services.StartTerminal(config, client, selectorParameter, args, imageSelector, exitChan, true, log)
 	} else if config.Dev == nil || config.Dev.Logs == nil || config.Dev.Logs.Disabled == nil || *config.Dev.Logs.Disabled == false {
-		// Build an image selector
-		imageSelector := []string{}
-		if config.Dev != nil && config.Dev.Logs != nil && config.Dev.Logs.Images != nil {
-			for generatedImageName, imageConfigCache := range generatedConfig.GetActive().Images {
-				if imageConfigCache.ImageName != "" {
-					// Check that they are also in the real config
-					for _, configImageName := range config.Dev.Logs.Images {
-						if configImageName == generatedImageName {
-							imageSelector = append(imageSelector, imageConfigCache.ImageName+":"+imageConfigCache.Tag)
-							break
-						}
-					}
-				}
+		logOptions, err := services.GetLogOptions(config, generatedConfig)
+		if err != nil {
+			return 0, err
+		}
+
+		// Stream the logs of dependencies that have all dev services enabled
+		for _, devDependency := range devDependencies {
+			if devDependency.IsDevEnabled() == false {
+				continue
 			}
-		} else {
-			for generatedImageName, imageConfigCache := range generatedConfig.GetActive().Images {
-				if imageConfigCache.ImageName != "" {
-					// Check that they are also in the real config
-					for configImageName := range config.Images {
-						if configImageName == generatedImageName {
-							imageSelector = append(imageSelector, imageConfigCache.ImageName+":"+imageConfigCache.Tag)
-							break
-						}
-					}
+
+			for imageName, imageConfigCache := range devDependency.GeneratedConfig.GetActive().Images {
+				if _, ok := devDependency.Config.Images[imageName]; ok && imageConfigCache.ImageName != "" {
+					logOptions.ImageSelector = append(logOptions.ImageSelector, imageConfigCache.ImageName+":"+imageConfigCache.Tag)
 				}
 			}
 		}
 
-		// Show last log lines
-		tail := int64(50)
-		if config.Dev != nil && config.Dev.Logs != nil && config.Dev.Logs.ShowLast != nil {
-			tail = int64(*config.Dev.Logs.ShowLast)
-		}
-
-		// Log multiple images at once
-		err := client.LogMultiple(imageSelector, exitChan, &tail, os.Stdout, log)
+		// Log multiple containers at once and attach to new pods, e.g. during a rollout
+		logOptions.Watch = true
+		err = client.LogMultipleWithOptions(logOptions, exitChan, os.Stdout, log)
 		if err != nil {
 			// Check if we should reload
 			if _, ok := err.(*reloadError); ok {
@@ -615,6 +673,11 @@ func (cmd *DevCmd) loadConfig() (*latest.Config, error) {
 			if cmd.Terminal {
 				imageConf.Entrypoint = nil
 				imageConf.Cmd = nil
+			} else if imageConf.Debug != nil {
+				err = debugger.Configure(config, imageConf, log.GetInstance())
+				if err != nil {
+					return nil, err
+				}
 			} else if imageConf.Entrypoint == nil && imageConf.Cmd == nil {
 				imageConf.Entrypoint = []string{"sleep"}
 				imageConf.Cmd = []string{"999999999"}
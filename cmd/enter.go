@@ -5,6 +5,7 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
 	"github.com/devspace-cloud/devspace/pkg/devspace/services"
 	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
@@ -23,6 +24,8 @@ type EnterCmd struct {
 	Container     string
 	Pod           string
 	Pick          bool
+	Session       string
+	DebugImage    string
 }
 
 // NewEnterCmd creates a new enter command
@@ -45,6 +48,8 @@ devspace enter bash
 devspace enter -c my-container
 devspace enter bash -n my-namespace
 devspace enter bash -l release=test
+devspace enter --session migrate # Attach to a persistent session
+devspace enter --debug-image busybox # Debug a container without shell
 #######################################################`,
 		RunE: cmd.Run,
 	}
@@ -54,6 +59,8 @@ devspace enter bash -l release=test
 	enterCmd.Flags().StringVarP(&cmd.LabelSelector, "label-selector", "l", "", "Comma separated key=value selector list (e.g. release=test)")
 
 	enterCmd.Flags().BoolVar(&cmd.Pick, "pick", false, "Select a pod")
+	enterCmd.Flags().StringVar(&cmd.Session, "session", "", "Attach to the persistent session with this name (dev.terminals) and start it if necessary")
+	enterCmd.Flags().StringVar(&cmd.DebugImage, "debug-image", "", "Open the terminal in a debug container with this image that shares the process namespace with the selected container (e.g. busybox)")
 
 	return enterCmd
 }
@@ -112,7 +119,16 @@ func (cmd *EnterCmd) Run(cobraCmd *cobra.Command, args []string) error {
 	}
 
 	// Start terminal
-	exitCode, err := services.StartTerminal(nil, client, selectorParameter, args, nil, make(chan error), false, log.GetInstance())
+	var exitCode int
+	if cmd.Session != "" && cmd.DebugImage != "" {
+		return errors.New("Flags --session and --debug-image cannot be used together")
+	} else if cmd.Session != "" {
+		exitCode, err = cmd.startSession(configExists, generatedConfig, client, &selectorParameter.CmdParameter, args)
+	} else if cmd.DebugImage != "" {
+		exitCode, err = services.StartDebugTerminal(client, selectorParameter, cmd.DebugImage, args, log.GetInstance())
+	} else {
+		exitCode, err = services.StartTerminal(nil, client, selectorParameter, args, nil, make(chan error), false, log.GetInstance())
+	}
 	if err != nil {
 		return err
 	} else if exitCode != 0 {
@@ -123,3 +139,27 @@ func (cmd *EnterCmd) Run(cobraCmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// startSession attaches to the session from dev.terminals or to a session with the given command
+func (cmd *EnterCmd) startSession(configExists bool, generatedConfig *generated.Config, client *kubectl.Client, cmdParameter *targetselector.CmdParameter, args []string) (int, error) {
+	var config *latest.Config
+	if configExists {
+		var err error
+		config, err = configutil.GetConfig(cmd.ToConfigOptions())
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	terminal := services.GetTerminalSession(config, cmd.Session)
+	if terminal == nil {
+		terminal = &latest.TerminalSessionConfig{
+			Name: cmd.Session,
+		}
+	}
+	if len(args) > 0 {
+		terminal.Command = args
+	}
+
+	return services.StartSessionTerminal(config, generatedConfig, client, terminal, cmdParameter, log.GetInstance())
+}
@@ -1,6 +1,8 @@
 package flags
 
 import (
+	"strings"
+
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
@@ -67,7 +69,7 @@ func SetGlobalFlags(flags *flag.FlagSet) *GlobalFlags {
 	flags.BoolVar(&globalFlags.Debug, "debug", false, "Prints the stack trace if an error occurs")
 	flags.BoolVar(&globalFlags.Silent, "silent", false, "Run in silent mode and prevents any devspace log output except panics & fatals")
 
-	flags.StringVarP(&globalFlags.Profile, "profile", "p", "", "The devspace profile to use (if there is any)")
+	flags.VarP(&profilesValue{value: &globalFlags.Profile}, "profile", "p", "The devspace profile to use (if there is any). Can be specified multiple times to activate several profiles (e.g. -p staging -p debug)")
 	flags.StringVarP(&globalFlags.Namespace, "namespace", "n", "", "The kubernetes namespace to use")
 	flags.StringVar(&globalFlags.KubeContext, "kube-context", "", "The kubernetes context to use")
 	flags.BoolVarP(&globalFlags.SwitchContext, "switch-context", "s", false, "Switches and uses the last kube context and namespace that was used to deploy the DevSpace project")
@@ -75,3 +77,35 @@ func SetGlobalFlags(flags *flag.FlagSet) *GlobalFlags {
 
 	return globalFlags
 }
+
+// profilesValue collects the values of multiple --profile flags in a single profile string, e.g. 'staging,debug'
+type profilesValue struct {
+	value *string
+}
+
+// Set implements the pflag.Value interface
+func (p *profilesValue) Set(value string) error {
+	profiles := configutil.SplitProfiles(*p.value)
+	for _, profile := range configutil.SplitProfiles(value) {
+		for _, existing := range profiles {
+			if existing == profile {
+				return errors.Errorf("Profile %s is specified more than once", profile)
+			}
+		}
+
+		profiles = append(profiles, profile)
+	}
+
+	*p.value = strings.Join(profiles, configutil.ProfileSeparator)
+	return nil
+}
+
+// Type implements the pflag.Value interface
+func (p *profilesValue) Type() string {
+	return "string"
+}
+
+// String implements the pflag.Value interface
+func (p *profilesValue) String() string {
+	return *p.value
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/generator"
 	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 	"github.com/devspace-cloud/devspace/pkg/util/survey"
 	"github.com/mgutz/ansi"
 	"github.com/pkg/errors"
@@ -323,7 +324,7 @@ func (cmd *InitCmd) addDevConfig() error {
 			servicePort := componentValues.Service.Ports[0]
 			if servicePort.Port != nil {
 				localPortPtr := servicePort.Port
-				var remotePortPtr *int
+				var remotePortPtr *string
 
 				if *localPortPtr < 1024 {
 					log.WriteString("\n")
@@ -337,7 +338,7 @@ func (cmd *InitCmd) addDevConfig() error {
 						return err
 					}
 
-					remotePortPtr = localPortPtr
+					remotePortPtr = ptr.String(strconv.Itoa(*localPortPtr))
 
 					localPort, err := strconv.Atoi(portString)
 					if err != nil {
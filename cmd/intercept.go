@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/devspace-cloud/devspace/cmd/flags"
+	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+)
+
+// InterceptCmd is a struct that defines a command call for "intercept"
+type InterceptCmd struct {
+	*flags.GlobalFlags
+
+	Ports []string
+	Image string
+}
+
+// NewInterceptCmd creates a new intercept command
+func NewInterceptCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &InterceptCmd{GlobalFlags: globalFlags}
+
+	interceptCmd := &cobra.Command{
+		Use:   "intercept [deployment]",
+		Short: "Forwards the traffic of a deployment to your local machine",
+		Long: `
+#######################################################
+################ devspace intercept ###################
+#######################################################
+Replaces the pods of a Kubernetes deployment with a proxy
+pod that forwards the traffic for the service ports to
+your local machine. The deployment is restored as soon
+as the command is stopped.
+
+devspace intercept my-deployment
+devspace intercept my-deployment --port 3000:8080
+devspace intercept my-deployment -n my-namespace
+#######################################################`,
+		Args: cobra.ExactArgs(1),
+		RunE: cmd.Run,
+	}
+
+	interceptCmd.Flags().StringSliceVar(&cmd.Ports, "port", []string{}, "Ports to forward in the form local:remote (Default: the target ports of all services that select the deployment)")
+	interceptCmd.Flags().StringVar(&cmd.Image, "image", services.DefaultInterceptImage, "Image of the proxy pod (needs sh and tar)")
+
+	return interceptCmd
+}
+
+// Run executes the command logic
+func (cmd *InterceptCmd) Run(cobraCmd *cobra.Command, args []string) error {
+	// Set config root
+	configExists, err := configutil.SetDevSpaceRoot(log.GetInstance())
+	if err != nil {
+		return err
+	}
+
+	// Load generated config if possible
+	var generatedConfig *generated.Config
+	if configExists {
+		generatedConfig, err = generated.LoadConfig(cmd.Profile)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Use last context if specified
+	err = cmd.UseLastContext(generatedConfig, log.GetInstance())
+	if err != nil {
+		return err
+	}
+
+	// Get kubectl client
+	client, err := kubectl.NewClientFromContext(cmd.KubeContext, cmd.Namespace, cmd.SwitchContext)
+	if err != nil {
+		return errors.Wrap(err, "new kube client")
+	}
+
+	err = client.PrintWarning(generatedConfig, cmd.NoWarn, false, log.GetInstance())
+	if err != nil {
+		return err
+	}
+
+	// Signal that we are working on the space if there is any
+	err = cloud.ResumeSpace(client, true, log.GetInstance())
+	if err != nil {
+		return err
+	}
+
+	// Stop the intercept on interrupt, so that the deployment is restored
+	stopChan := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	go func() {
+		<-signals
+		close(stopChan)
+	}()
+
+	return services.StartIntercept(client, &services.InterceptOptions{
+		Deployment: args[0],
+		Namespace:  cmd.Namespace,
+		Image:      cmd.Image,
+		Ports:      cmd.Ports,
+	}, stopChan, log.GetInstance())
+}
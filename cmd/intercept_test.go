@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"gotest.tools/assert"
+)
+
+func TestInterceptFlags(t *testing.T) {
+	interceptCmd, _, err := rootCmd.Find([]string{"intercept"})
+	assert.NilError(t, err)
+	assert.Equal(t, "intercept", interceptCmd.Name())
+
+	// Parsing merges the persistent flags of the root command and panics on conflicting shorthands
+	err = parseFlagsRecover(interceptCmd, []string{"--port", "3000:8080", "-p", "staging", "-n", "my-namespace"})
+	assert.NilError(t, err)
+
+	ports, err := interceptCmd.Flags().GetStringSlice("port")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"3000:8080"}, ports)
+	assert.Equal(t, "staging", globalFlags.Profile)
+	assert.Equal(t, "my-namespace", globalFlags.Namespace)
+}
+
+func TestCommandFlagsDoNotConflict(t *testing.T) {
+	var check func(cobraCmd *cobra.Command)
+	check = func(cobraCmd *cobra.Command) {
+		err := parseFlagsRecover(cobraCmd, []string{})
+		assert.NilError(t, err, "Command %s", cobraCmd.CommandPath())
+
+		for _, child := range cobraCmd.Commands() {
+			check(child)
+		}
+	}
+
+	check(rootCmd)
+}
+
+func parseFlagsRecover(cobraCmd *cobra.Command, args []string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	return cobraCmd.ParseFlags(args)
+}
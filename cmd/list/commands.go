@@ -8,11 +8,7 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/pkg/errors"
 
-	"io/ioutil"
-
 	"github.com/spf13/cobra"
-
-	yaml "gopkg.in/yaml.v2"
 )
 
 type commandsCmd struct {
@@ -52,12 +48,7 @@ func (cmd *commandsCmd) RunListProfiles(cobraCmd *cobra.Command, args []string)
 	}
 
 	// Load commands
-	bytes, err := ioutil.ReadFile(constants.DefaultConfigPath)
-	if err != nil {
-		return err
-	}
-	rawMap := map[interface{}]interface{}{}
-	err = yaml.Unmarshal(bytes, &rawMap)
+	rawMap, err := configutil.GetRawConfigWithImports(constants.DefaultConfigPath)
 	if err != nil {
 		return err
 	}
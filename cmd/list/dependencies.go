@@ -0,0 +1,125 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/cmd/flags"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/dependency"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type dependenciesCmd struct {
+	*flags.GlobalFlags
+
+	Output     string
+	FrozenLock bool
+}
+
+func newDependenciesCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &dependenciesCmd{GlobalFlags: globalFlags}
+
+	dependenciesCmd := &cobra.Command{
+		Use:   "dependencies",
+		Short: "Lists the resolved dependency tree",
+		Long: `
+#######################################################
+############ devspace list dependencies ###############
+#######################################################
+Resolves all dependencies and shows the dependency tree
+with the resolved revisions and whether a dependency
+would be rebuilt or redeployed. Dependencies that are
+required by more than one project and cyclic
+dependencies are reported as well.
+
+Nothing is written to devspace.lock or the generated
+config. Use --frozen to fail if devspace.lock is
+missing or out of date.
+
+Use -o dot or -o json to print the graph for other tools:
+devspace list dependencies -o dot | dot -Tpng > deps.png
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		RunE: cmd.RunListDependencies,
+	}
+
+	dependenciesCmd.Flags().StringVarP(&cmd.Output, "output", "o", "tree", "Output format: tree, dot or json")
+	dependenciesCmd.Flags().BoolVar(&cmd.FrozenLock, "frozen", false, "Fails if devspace.lock is missing or out of date")
+
+	return dependenciesCmd
+}
+
+// RunListDependencies runs the list dependencies command logic
+func (cmd *dependenciesCmd) RunListDependencies(cobraCmd *cobra.Command, args []string) error {
+	output := strings.ToLower(strings.TrimSpace(cmd.Output))
+	if output != "tree" && output != "dot" && output != "json" {
+		return errors.Errorf("Unknown output format %s, expected tree, dot or json", cmd.Output)
+	}
+
+	// The graph is printed to stdout, so we only want to see errors
+	if output != "tree" && log.GetInstance().GetLevel() > logrus.ErrorLevel {
+		log.GetInstance().SetLevel(logrus.ErrorLevel)
+	}
+
+	// Set config root
+	configExists, err := configutil.SetDevSpaceRoot(log.GetInstance())
+	if err != nil {
+		return err
+	}
+	if !configExists {
+		return errors.New("Couldn't find a DevSpace configuration. Please run `devspace init`")
+	}
+
+	// Load generated config
+	generatedConfig, err := generated.LoadConfig(cmd.Profile)
+	if err != nil {
+		return err
+	}
+
+	config, err := configutil.GetConfig(cmd.ToConfigOptions())
+	if err != nil {
+		return err
+	}
+
+	analysis, err := dependency.Analyze(config, generatedConfig, cmd.FrozenLock, cmd.ToConfigOptions(), log.Discard)
+	if err != nil {
+		return errors.Wrap(err, "analyze dependencies")
+	}
+
+	switch output {
+	case "json":
+		out, err := json.MarshalIndent(analysis, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stdout, string(out))
+	case "dot":
+		fmt.Fprint(os.Stdout, analysis.DOT())
+	default:
+		if len(analysis.Dependencies) == 0 {
+			log.Info("No dependencies found")
+			return nil
+		}
+
+		log.WriteString(analysis.Tree())
+
+		for _, id := range analysis.Diamonds {
+			log.Infof("Dependency %s is required by multiple projects and will only be deployed once", id)
+		}
+		for _, cycle := range analysis.Cycles {
+			log.Warnf("Cyclic dependency found: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	return nil
+}
@@ -25,6 +25,7 @@ func NewListCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
 	listCmd.AddCommand(newProfilesCmd())
 	listCmd.AddCommand(newVarsCmd(globalFlags))
 	listCmd.AddCommand(newDeploymentsCmd(globalFlags))
+	listCmd.AddCommand(newDependenciesCmd(globalFlags))
 	listCmd.AddCommand(newProvidersCmd())
 	listCmd.AddCommand(newAvailableComponentsCmd())
 	listCmd.AddCommand(newContextsCmd())
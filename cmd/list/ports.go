@@ -81,12 +81,12 @@ func (cmd *portsCmd) RunListPort(cobraCmd *cobra.Command, args []string) error {
 					portMappings += ", "
 				}
 
-				remotePort := *v.LocalPort
+				remotePort := strconv.Itoa(*v.LocalPort)
 				if v.RemotePort != nil {
 					remotePort = *v.RemotePort
 				}
 
-				portMappings += strconv.Itoa(*v.LocalPort) + ":" + strconv.Itoa(remotePort)
+				portMappings += strconv.Itoa(*v.LocalPort) + ":" + remotePort
 			}
 		}
 
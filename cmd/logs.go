@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"os"
+	"time"
+
 	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
@@ -9,6 +12,7 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/services"
 	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -24,6 +28,16 @@ type LogsCmd struct {
 
 	Follow            bool
 	LastAmountOfLines int
+
+	All         bool
+	Deployments []string
+	Include     []string
+	Exclude     []string
+	JSON        bool
+
+	Session string
+	Since   time.Duration
+	Sources []string
 }
 
 // NewLogsCmd creates a new login command
@@ -43,6 +57,10 @@ to it
 Example:
 devspace logs
 devspace logs --namespace=mynamespace
+devspace logs -f --all # Stream the logs of dev.logs
+devspace logs -f --deployment api --deployment worker
+devspace logs -f --all -l app=api --exclude healthz --json
+devspace logs --session last --since 10m # Replay the last devspace dev session
 #######################################################
 	`,
 		Args: cobra.NoArgs,
@@ -56,6 +74,16 @@ devspace logs --namespace=mynamespace
 	logsCmd.Flags().BoolVarP(&cmd.Follow, "follow", "f", false, "Attach to logs afterwards")
 	logsCmd.Flags().IntVar(&cmd.LastAmountOfLines, "lines", 200, "Max amount of lines to print from the last log")
 
+	logsCmd.Flags().BoolVar(&cmd.All, "all", false, "Print the logs of all selected pods (label selector or dev.logs) instead of a single container")
+	logsCmd.Flags().StringSliceVar(&cmd.Deployments, "deployment", []string{}, "Print the logs of all pods of these Kubernetes deployments")
+	logsCmd.Flags().StringSliceVar(&cmd.Include, "include", []string{}, "Only print lines that match one of these regular expressions")
+	logsCmd.Flags().StringSliceVar(&cmd.Exclude, "exclude", []string{}, "Don't print lines that match one of these regular expressions")
+	logsCmd.Flags().BoolVar(&cmd.JSON, "json", false, "Pretty print json log lines and highlight their log level")
+
+	logsCmd.Flags().StringVar(&cmd.Session, "session", "", "Print the record of a devspace dev session (name of the record or 'last')")
+	logsCmd.Flags().DurationVar(&cmd.Since, "since", 0, "Only print session record entries that are newer than this duration (e.g. 10m)")
+	logsCmd.Flags().StringSliceVar(&cmd.Sources, "source", []string{}, "Only print session record entries of these sources (devspace, build, deploy, sync, logs)")
+
 	return logsCmd
 }
 
@@ -67,6 +95,13 @@ func (cmd *LogsCmd) RunLogs(cobraCmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Replay a recorded session
+	if cmd.Session != "" {
+		return cmd.replaySession()
+	} else if cmd.Since != 0 || len(cmd.Sources) > 0 {
+		return errors.New("Flags --since and --source can only be used together with --session")
+	}
+
 	// Load generated config if possible
 	var generatedConfig *generated.Config
 	if configExists {
@@ -99,6 +134,16 @@ func (cmd *LogsCmd) RunLogs(cobraCmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Print the logs of multiple pods
+	if cmd.All || len(cmd.Deployments) > 0 {
+		return cmd.logMultiple(configExists, generatedConfig, client)
+	}
+
+	formatter, err := cmd.getFormatter(nil)
+	if err != nil {
+		return err
+	}
+
 	// Build params
 	params := targetselector.CmdParameter{
 		ContainerName: cmd.Container,
@@ -111,10 +156,90 @@ func (cmd *LogsCmd) RunLogs(cobraCmd *cobra.Command, args []string) error {
 	}
 
 	// Start terminal
-	err = services.StartLogs(nil, client, params, cmd.Follow, int64(cmd.LastAmountOfLines), log.GetInstance())
+	err = services.StartLogs(nil, client, params, cmd.Follow, int64(cmd.LastAmountOfLines), formatter, log.GetInstance())
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// logMultiple prints the logs of all pods selected by the flags. If neither a label selector nor deployments are
+// specified, the containers of dev.logs are selected
+func (cmd *LogsCmd) logMultiple(configExists bool, generatedConfig *generated.Config, client *kubectl.Client) error {
+	logOptions := &kubectl.LogOptions{}
+	if cmd.LabelSelector == "" && len(cmd.Deployments) == 0 {
+		if configExists == false {
+			return errors.New("Please specify a label selector (-l) or a deployment (--deployment) or run this command within a DevSpace project")
+		}
+
+		config, err := configutil.GetConfig(cmd.ToConfigOptions())
+		if err != nil {
+			return err
+		}
+
+		logOptions, err = services.GetLogOptions(config, generatedConfig)
+		if err != nil {
+			return err
+		}
+	} else {
+		logOptions.LabelSelector = cmd.LabelSelector
+		logOptions.Deployments = cmd.Deployments
+	}
+
+	formatter, err := cmd.getFormatter(logOptions.Formatter)
+	if err != nil {
+		return err
+	}
+
+	logOptions.Namespace = cmd.Namespace
+	logOptions.Tail = ptr.Int64(int64(cmd.LastAmountOfLines))
+	logOptions.Formatter = formatter
+	logOptions.Watch = cmd.Follow
+	logOptions.NoFollow = cmd.Follow == false
+	logOptions.Timeout = time.Minute * 2
+
+	return client.LogMultipleWithOptions(logOptions, make(chan error), os.Stdout, log.GetInstance())
+}
+
+// replaySession prints the filtered entries of the session record
+func (cmd *LogsCmd) replaySession() error {
+	formatter, err := cmd.getFormatter(nil)
+	if err != nil {
+		return err
+	}
+
+	options := &services.SessionRecordOptions{
+		Sources:   cmd.Sources,
+		Pod:       cmd.Pod,
+		Container: cmd.Container,
+		Formatter: formatter,
+	}
+	if cmd.Since > 0 {
+		options.Since = time.Now().Add(-cmd.Since)
+	}
+
+	return services.ReplaySessionRecord(cmd.Session, options, os.Stdout)
+}
+
+// getFormatter returns the formatter for the filter flags. Include patterns of the flags replace the include
+// patterns of the given formatter, exclude patterns are added
+func (cmd *LogsCmd) getFormatter(formatter *kubectl.LogFormatter) (*kubectl.LogFormatter, error) {
+	if formatter == nil && len(cmd.Include) == 0 && len(cmd.Exclude) == 0 && cmd.JSON == false {
+		return nil, nil
+	}
+
+	newFormatter, err := kubectl.NewLogFormatter(cmd.Include, cmd.Exclude, cmd.JSON)
+	if err != nil {
+		return nil, err
+	}
+	if formatter != nil {
+		if len(newFormatter.Include) == 0 {
+			newFormatter.Include = formatter.Include
+		}
+		newFormatter.Exclude = append(formatter.Exclude, newFormatter.Exclude...)
+		newFormatter.FormatJSON = newFormatter.FormatJSON || formatter.FormatJSON
+	}
+
+	return newFormatter, nil
+}
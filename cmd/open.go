@@ -23,6 +23,7 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/util/kubeconfig"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/devspace-cloud/devspace/pkg/util/port"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 	"github.com/devspace-cloud/devspace/pkg/util/survey"
 
 	"github.com/mgutz/ansi"
@@ -351,7 +352,7 @@ func openLocal(devspaceConfig *latest.Config, generatedConfig *generated.Config,
 	portMappings := []*latest.PortMapping{
 		&latest.PortMapping{
 			LocalPort:  &localPort,
-			RemotePort: &servicePort,
+			RemotePort: ptr.String(strconv.Itoa(servicePort)),
 		},
 	}
 
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/cmd/flags"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	deploy "github.com/devspace-cloud/devspace/pkg/devspace/deploy/util"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// RenderCmd holds the required data for the render cmd
+type RenderCmd struct {
+	*flags.GlobalFlags
+
+	Deployments string
+	OutputDir   string
+}
+
+// NewRenderCmd creates a new render command
+func NewRenderCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &RenderCmd{GlobalFlags: globalFlags}
+
+	renderCmd := &cobra.Command{
+		Use:   "render",
+		Short: "Renders the kubernetes manifests of all deployments",
+		Long: `
+#######################################################
+################## devspace render ####################
+#######################################################
+Renders the final kubernetes manifests of all deployments
+without deploying them. Image tags are replaced with the
+tags of the last build (run devspace build beforehand):
+
+devspace render
+devspace render --output-dir manifests
+devspace render --deployments api,database
+#######################################################`,
+		Args: cobra.NoArgs,
+		RunE: cmd.Run,
+	}
+
+	renderCmd.Flags().StringVar(&cmd.Deployments, "deployments", "", "Only render specific deployments (You can specify multiple deployments comma-separated)")
+	renderCmd.Flags().StringVarP(&cmd.OutputDir, "output-dir", "o", "", "Writes the manifests of each deployment into a separate file in this directory instead of printing them")
+
+	return renderCmd
+}
+
+// Run executes the render command logic
+func (cmd *RenderCmd) Run(cobraCmd *cobra.Command, args []string) error {
+	// The manifests are printed to stdout, so we only want to see errors
+	if cmd.OutputDir == "" && log.GetInstance().GetLevel() > logrus.ErrorLevel {
+		log.GetInstance().SetLevel(logrus.ErrorLevel)
+	}
+
+	// Set config root
+	configExists, err := configutil.SetDevSpaceRoot(log.GetInstance())
+	if err != nil {
+		return err
+	}
+	if !configExists {
+		return errors.New("Couldn't find a DevSpace configuration. Please run `devspace init`")
+	}
+
+	// Load generated config
+	generatedConfig, err := generated.LoadConfig(cmd.Profile)
+	if err != nil {
+		return errors.Errorf("Error loading generated.yaml: %v", err)
+	}
+
+	// Use last context if specified
+	err = cmd.UseLastContext(generatedConfig, log.GetInstance())
+	if err != nil {
+		return err
+	}
+
+	// Create kubectl client
+	client, err := kubectl.NewClientFromContext(cmd.KubeContext, cmd.Namespace, cmd.SwitchContext)
+	if err != nil {
+		return errors.Errorf("Unable to create new kubectl client: %v", err)
+	}
+
+	config, err := configutil.GetConfig(cmd.ToConfigOptions())
+	if err != nil {
+		return err
+	}
+
+	deployments := []string{}
+	if cmd.Deployments != "" {
+		deployments = strings.Split(cmd.Deployments, ",")
+		for index := range deployments {
+			deployments[index] = strings.TrimSpace(deployments[index])
+		}
+	}
+
+	rendered, err := deploy.Render(config, generatedConfig.GetActive(), client, deployments, log.GetInstance())
+	if err != nil {
+		return err
+	}
+
+	// Print to stdout
+	if cmd.OutputDir == "" {
+		for idx, deployment := range rendered {
+			if idx > 0 {
+				fmt.Fprint(os.Stdout, "---\n")
+			}
+
+			fmt.Fprint(os.Stdout, deployment.Manifests)
+		}
+
+		return nil
+	}
+
+	for _, deployment := range rendered {
+		filename := filepath.Join(cmd.OutputDir, deployment.Name+".yaml")
+		err = fsutil.WriteToFile([]byte(deployment.Manifests), filename)
+		if err != nil {
+			return errors.Errorf("Error writing %s: %v", filename, err)
+		}
+
+		log.Donef("Rendered deployment %s to %s", deployment.Name, filename)
+	}
+
+	return nil
+}
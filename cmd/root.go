@@ -103,6 +103,7 @@ func init() {
 	rootCmd.AddCommand(NewPurgeCmd(globalFlags))
 	rootCmd.AddCommand(NewUpgradeCmd())
 	rootCmd.AddCommand(NewDeployCmd(globalFlags))
+	rootCmd.AddCommand(NewRenderCmd(globalFlags))
 	rootCmd.AddCommand(NewEnterCmd(globalFlags))
 	rootCmd.AddCommand(NewLoginCmd())
 	rootCmd.AddCommand(NewAnalyzeCmd(globalFlags))
@@ -111,6 +112,7 @@ func init() {
 	rootCmd.AddCommand(NewUICmd(globalFlags))
 	rootCmd.AddCommand(NewRunCmd(globalFlags))
 	rootCmd.AddCommand(NewAttachCmd(globalFlags))
+	rootCmd.AddCommand(NewInterceptCmd(globalFlags))
 
 	cobra.OnInitialize(initConfig)
 }
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"io/ioutil"
-
 	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/command"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
@@ -13,7 +11,6 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	yaml "gopkg.in/yaml.v2"
 	"mvdan.cc/sh/v3/interp"
 )
 
@@ -60,12 +57,7 @@ func (cmd *RunCmd) RunRun(cobraCmd *cobra.Command, args []string) error {
 	}
 
 	// Load commands
-	bytes, err := ioutil.ReadFile(constants.DefaultConfigPath)
-	if err != nil {
-		return err
-	}
-	rawMap := map[interface{}]interface{}{}
-	err = yaml.Unmarshal(bytes, &rawMap)
+	rawMap, err := configutil.GetRawConfigWithImports(constants.DefaultConfigPath)
 	if err != nil {
 		return err
 	}
@@ -1,7 +1,6 @@
 package set
 
 import (
-	"io/ioutil"
 	"strings"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
@@ -12,7 +11,6 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	yaml "gopkg.in/yaml.v2"
 )
 
 type varCmd struct{}
@@ -95,12 +93,7 @@ func (cmd *varCmd) RunSetVar(cobraCmd *cobra.Command, args []string) error {
 
 func getPossibleVars(generatedConfig *generated.Config, log log.Logger) (map[string]bool, error) {
 	// Load variables
-	bytes, err := ioutil.ReadFile(constants.DefaultConfigPath)
-	if err != nil {
-		return nil, err
-	}
-	rawMap := map[interface{}]interface{}{}
-	err = yaml.Unmarshal(bytes, &rawMap)
+	rawMap, err := configutil.GetRawConfigWithImports(constants.DefaultConfigPath)
 	if err != nil {
 		return nil, err
 	}
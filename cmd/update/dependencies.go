@@ -24,13 +24,14 @@ func newDependenciesCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
 
 	dependenciesCmd := &cobra.Command{
 		Use:   "dependencies",
-		Short: "Updates the git repositories of the dependencies defined in the devspace.yaml",
+		Short: "Updates the git repositories of the dependencies defined in the devspace.yaml and devspace.lock",
 		Long: `
 #######################################################
 ############ devspace update dependencies #############
 #######################################################
 Updates the git repositories of the dependencies defined
-in the devspace.yaml
+in the devspace.yaml and records the new revisions in
+devspace.lock
 #######################################################
 	`,
 		Args: cobra.NoArgs,
@@ -29,6 +29,7 @@ Use a specific DevSpace profile
 
 Example:
 devspace use profile myconfig
+devspace use profile staging,debug
 devspace use profile --reset
 #######################################################
 	`,
@@ -71,17 +72,19 @@ func (cmd *profileCmd) RunUseProfile(cobraCmd *cobra.Command, args []string) err
 			}
 		}
 
-		// Check if config exists
-		found := false
-		for _, profile := range profiles {
-			if profile == profileName {
-				found = true
-				break
+		// Check if all profiles exist
+		for _, name := range configutil.SplitProfiles(profileName) {
+			found := false
+			for _, profile := range profiles {
+				if profile == name {
+					found = true
+					break
+				}
 			}
-		}
 
-		if found == false {
-			return errors.Errorf("Profile '%s' does not exist in devspace.yaml", profileName)
+			if found == false {
+				return errors.Errorf("Profile '%s' does not exist in devspace.yaml", name)
+			}
 		}
 	}
 
@@ -73,6 +73,7 @@ require (
 	github.com/rubenv/sql-migrate v0.0.0-20190327083759-54bad0a9b051 // indirect
 	github.com/russross/blackfriday v1.5.1 // indirect
 	github.com/sabhiram/go-gitignore v0.0.0-20180611051255-d3107576ba94
+	github.com/sergi/go-diff v1.0.0
 	github.com/shirou/gopsutil v0.0.0-20190627142359-4c8b404ee5c5
 	github.com/sirupsen/logrus v1.2.0
 	github.com/skratchdot/open-golang v0.0.0-20160302144031-75fb7ed4208c
@@ -89,6 +90,7 @@ require (
 	github.com/uudashr/gopkgs v2.0.1+incompatible // indirect
 	golang.org/x/lint v0.0.0-20190909230951-414d861bb4ac // indirect
 	golang.org/x/sync v0.0.0-20190911185100-cd5d95a43a6e // indirect
+	golang.org/x/sys v0.0.0-20190813064441-fde4db37ae7a
 	golang.org/x/time v0.0.0-20181108054448-85acf8d2951c // indirect
 	golang.org/x/tools v0.0.0-20191025023517-2077df36852e // indirect
 	golang.org/x/tools/gopls v0.1.7 // indirect
@@ -106,11 +108,13 @@ require (
 	gotest.tools v2.2.0+incompatible
 	k8s.io/api v0.0.0
 	k8s.io/apimachinery v0.0.0
+	k8s.io/cli-runtime v0.0.0
 	k8s.io/client-go v0.0.0
 	k8s.io/helm v2.15.0+incompatible
 	k8s.io/klog v0.3.1
 	k8s.io/kubernetes v1.15.0
 	mvdan.cc/sh/v3 v3.0.0-alpha2
+	sigs.k8s.io/kustomize v2.0.3+incompatible
 	vbom.ml/util v0.0.0-20180919145318-efcd4e0f9787 // indirect
 )
 
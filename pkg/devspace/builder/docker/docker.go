@@ -122,7 +122,7 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []st
 	// Determine output writer
 	var writer io.Writer
 	if log == logpkg.GetInstance() {
-		writer = stdout
+		writer = logpkg.RecordWriter(stdout)
 	} else {
 		writer = log
 	}
@@ -1,7 +1,9 @@
 package command
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"os"
 	"strings"
 
@@ -33,20 +35,41 @@ func ExecuteCommand(commands []*latest.CommandConfig, name string, args []string
 		shellCommand += " '" + arg + "'"
 	}
 
-	// Let's parse the complete command
-	file, err := syntax.NewParser().Parse(strings.NewReader(shellCommand), "")
-	if err != nil {
-		return errors.Wrap(err, "parse shell command")
-	}
-
 	// Get current working directory
 	pwd, err := os.Getwd()
 	if err != nil {
 		return err
 	}
 
+	return runShellCommand(shellCommand, pwd, os.Stdin, os.Stdout, os.Stderr)
+}
+
+// OutputCommand executes a shell command in the given directory and returns what the command printed to stdout
+func OutputCommand(shellCommand, dir string) (string, error) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	err := runShellCommand(shellCommand, dir, nil, stdout, stderr)
+	if err != nil {
+		if stderr.Len() > 0 {
+			return "", errors.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+		}
+
+		return "", err
+	}
+
+	return stdout.String(), nil
+}
+
+func runShellCommand(shellCommand, dir string, stdin io.Reader, stdout, stderr io.Writer) error {
+	// Let's parse the complete command
+	file, err := syntax.NewParser().Parse(strings.NewReader(shellCommand), "")
+	if err != nil {
+		return errors.Wrap(err, "parse shell command")
+	}
+
 	// Create shell runner
-	r, err := interp.New(interp.Dir(pwd), interp.StdIO(os.Stdin, os.Stdout, os.Stderr))
+	r, err := interp.New(interp.Dir(dir), interp.StdIO(stdin, stdout, stderr))
 	if err != nil {
 		return errors.Wrap(err, "create shell runner")
 	}
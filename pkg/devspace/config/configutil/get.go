@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sync"
 
 	homedir "github.com/mitchellh/go-homedir"
@@ -61,6 +62,10 @@ func ResetConfig() {
 	defer getConfigOnceMutex.Unlock()
 
 	getConfigOnce = sync.Once{}
+
+	varsContextsMutex.Lock()
+	varsContexts = map[*latest.Config]*varsContext{}
+	varsContextsMutex.Unlock()
 }
 
 // InitConfig initializes the config objects
@@ -82,6 +87,12 @@ type ConfigOptions struct {
 
 	LoadedVars map[string]string
 	Vars       []string
+
+	// Overrides are patches that are applied to the config after the profile
+	Overrides []*latest.PatchConfig
+
+	// BasePath is the folder of the loaded config. Relative paths of variable sources are resolved against it
+	BasePath string
 }
 
 // Clone clones the config options
@@ -146,12 +157,17 @@ func GetConfigFromPath(generatedConfig *generated.Config, basePath string, optio
 		return nil, errors.Errorf("Couldn't find '%s': %v", configPath, err)
 	}
 
-	rawMap, err := GetRawConfig(configPath)
+	// Load the config together with all imported config files
+	rawMap, err := GetRawConfigWithImports(configPath)
 	if err != nil {
 		return nil, err
 	}
 
-	loadedConfig, err := ParseConfig(generatedConfig, rawMap, options, log)
+	// LoadedVars are shared, because callers read them after loading
+	parseOptions := *options
+	parseOptions.BasePath = basePath
+
+	loadedConfig, err := ParseConfig(generatedConfig, rawMap, &parseOptions, log)
 	if err != nil {
 		return nil, err
 	}
@@ -214,12 +230,17 @@ func validate(config *latest.Config) error {
 	if config.Dev != nil {
 		if config.Dev.Ports != nil {
 			for index, port := range config.Dev.Ports {
-				if port.ImageName == "" && port.LabelSelector == nil {
-					return errors.Errorf("Error in config: imageName and label selector are nil in port config at index %d", index)
+				if port.ImageName == "" && port.LabelSelector == nil && port.Service == "" {
+					return errors.Errorf("Error in config: imageName, label selector and service are nil in port config at index %d", index)
 				}
-				if port.PortMappings == nil {
+				if port.PortMappings == nil && port.ReverseForward == nil {
 					return errors.Errorf("Error in config: portMappings is empty in port config at index %d", index)
 				}
+				for mappingIndex, mapping := range port.ReverseForward {
+					if mapping.LocalPort == nil {
+						return errors.Errorf("Error in config: port is not defined in reverseForward %d of port config at index %d", mappingIndex, index)
+					}
+				}
 			}
 		}
 
@@ -231,11 +252,43 @@ func validate(config *latest.Config) error {
 			}
 		}
 
+		if config.Dev.Terminals != nil {
+			names := map[string]bool{}
+			for index, terminal := range config.Dev.Terminals {
+				if terminal.Name == "" {
+					return errors.Errorf("Error in config: dev.terminals[%d].name is required", index)
+				} else if names[terminal.Name] {
+					return errors.Errorf("Error in config: dev.terminals[%d].name %s is used more than once", index, terminal.Name)
+				}
+				if terminal.ImageName == "" && terminal.LabelSelector == nil {
+					return errors.Errorf("Error in config: imageName and label selector are nil in terminal config at index %d", index)
+				}
+
+				names[terminal.Name] = true
+			}
+		}
+
+		if config.Dev.Logs != nil {
+			for index, pattern := range config.Dev.Logs.Include {
+				if _, err := regexp.Compile(pattern); err != nil {
+					return errors.Errorf("Error in config: dev.logs.include[%d] is no valid regular expression: %v", index, err)
+				}
+			}
+			for index, pattern := range config.Dev.Logs.Exclude {
+				if _, err := regexp.Compile(pattern); err != nil {
+					return errors.Errorf("Error in config: dev.logs.exclude[%d] is no valid regular expression: %v", index, err)
+				}
+			}
+		}
+
 		if config.Dev.Interactive != nil {
 			for index, imageConf := range config.Dev.Interactive.Images {
 				if imageConf.Name == "" {
 					return errors.Errorf("Error in config: Unnamed interactive image config at index %d", index)
 				}
+				if imageConf.Debug != nil && imageConf.Debug.Language == "" {
+					return errors.Errorf("Error in config: dev.interactive.images[%d].debug.language is required", index)
+				}
 			}
 		}
 	}
@@ -0,0 +1,264 @@
+package configutil
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/util"
+	"github.com/devspace-cloud/devspace/pkg/util/git"
+	"github.com/devspace-cloud/devspace/pkg/util/hash"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ImportsFolder is the folder in the home directory of the user where imported git repositories are cloned to
+const ImportsFolder = ".devspace/imports"
+
+var importsKeyRegEx = regexp.MustCompile(`^imports\s*:`)
+var listItemRegEx = regexp.MustCompile(`^(\s*)-(\s|$)`)
+
+// GetRawConfigWithImports loads the raw config from the given path and merges all imported config files into it
+func GetRawConfigWithImports(configPath string) (map[interface{}]interface{}, error) {
+	return loadImportFile(configPath, []string{})
+}
+
+// loadImportFile loads a config file and all of its imports. Stack holds the files that are currently imported
+func loadImportFile(configPath string, stack []string) (map[interface{}]interface{}, error) {
+	absPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, importing := range stack {
+		if importing == absPath {
+			return nil, errors.Errorf("Cyclic import found: %s", strings.Join(append(stack[idx:], absPath), " -> "))
+		}
+	}
+	stack = append(append([]string{}, stack...), absPath)
+
+	content, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	rawMap := map[interface{}]interface{}{}
+	err = yaml.Unmarshal(content, &rawMap)
+	if err != nil {
+		return nil, errors.Errorf("Error parsing %s: %v", configPath, err)
+	}
+
+	if rawMap["imports"] == nil {
+		return rawMap, nil
+	}
+
+	imports := []*latest.ImportConfig{}
+	err = util.Convert(rawMap["imports"], &imports)
+	if err != nil {
+		return nil, errors.Errorf("%s: imports: %v", configPath, err)
+	}
+	delete(rawMap, "imports")
+
+	// Later imports take precedence over earlier ones and the importing file over all imports
+	merged := map[interface{}]interface{}{}
+	for idx, importConfig := range imports {
+		importPath, err := resolveImportPath(filepath.Dir(absPath), importConfig)
+		if err == nil {
+			var imported map[interface{}]interface{}
+			imported, err = loadImportFile(importPath, stack)
+			if err == nil {
+				err = checkImportVersion(rawMap, imported, configPath, importPath)
+				if err == nil {
+					merged = mergeImport(imported, merged)
+				}
+			}
+		}
+		if err != nil {
+			return nil, errors.Errorf("%s: import %s: %v", formatImportLocation(configPath, content, idx), importName(importConfig), err)
+		}
+	}
+
+	return mergeImport(rawMap, merged), nil
+}
+
+// resolveImportPath returns the path of the config file that should be imported. Git repositories are cloned
+// into the imports folder if they do not exist yet
+func resolveImportPath(basePath string, importConfig *latest.ImportConfig) (string, error) {
+	if importConfig.Git == "" {
+		if importConfig.Path == "" {
+			return "", errors.New("please specify either path or git")
+		}
+
+		return filepath.Join(basePath, filepath.FromSlash(importConfig.Path)), nil
+	}
+
+	homedir, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		gitPath   = strings.TrimSpace(importConfig.Git)
+		localPath = filepath.Join(homedir, filepath.FromSlash(ImportsFolder), hash.String(gitPath))
+		gitRepo   = git.NewGitRepository(localPath, gitPath)
+	)
+
+	if _, err := os.Stat(localPath); os.IsNotExist(err) {
+		err = gitRepo.Update(importConfig.Tag == "" && importConfig.Branch == "" && importConfig.Revision == "")
+		if err != nil {
+			return "", errors.Wrap(err, "pull repo")
+		}
+	}
+	if importConfig.Tag != "" || importConfig.Branch != "" || importConfig.Revision != "" {
+		err = gitRepo.Checkout(importConfig.Tag, importConfig.Branch, importConfig.Revision)
+		if err != nil {
+			return "", errors.Wrap(err, "checkout")
+		}
+	}
+
+	path := importConfig.Path
+	if path == "" {
+		path = "devspace.yaml"
+	}
+
+	return filepath.Join(localPath, filepath.FromSlash(path)), nil
+}
+
+// checkImportVersion makes sure that imported files use the same config version as the importing file
+func checkImportVersion(rawMap, imported map[interface{}]interface{}, configPath, importPath string) error {
+	importedVersion, ok := imported["version"]
+	if !ok {
+		return nil
+	}
+
+	delete(imported, "version")
+	if version, ok := rawMap["version"]; ok && version != importedVersion {
+		return errors.Errorf("%s uses config version %v, but %s uses %v. Imported files have to use the same version", importPath, importedVersion, configPath, version)
+	}
+
+	return nil
+}
+
+// mergeImport merges the imported config into the config. Values of the config take precedence over imported
+// values. Lists are concatenated, but imported items are dropped if the config contains an item with the same name
+func mergeImport(config, imported map[interface{}]interface{}) map[interface{}]interface{} {
+	merged := map[interface{}]interface{}{}
+	for key, value := range imported {
+		merged[key] = value
+	}
+
+	for key, value := range config {
+		switch configValue := value.(type) {
+		case map[interface{}]interface{}:
+			if importedMap, ok := merged[key].(map[interface{}]interface{}); ok {
+				merged[key] = mergeImport(configValue, importedMap)
+				continue
+			}
+		case []interface{}:
+			if importedArray, ok := merged[key].([]interface{}); ok {
+				merged[key] = mergeImportLists(configValue, importedArray)
+				continue
+			}
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
+
+func mergeImportLists(config, imported []interface{}) []interface{} {
+	names := map[string]bool{}
+	for _, item := range config {
+		if itemMap, ok := item.(map[interface{}]interface{}); ok && itemMap["name"] != nil {
+			names[fmt.Sprintf("%v", itemMap["name"])] = true
+		}
+	}
+
+	merged := []interface{}{}
+	for _, item := range imported {
+		if itemMap, ok := item.(map[interface{}]interface{}); ok && itemMap["name"] != nil && names[fmt.Sprintf("%v", itemMap["name"])] {
+			continue
+		}
+
+		merged = append(merged, item)
+	}
+
+	return append(merged, config...)
+}
+
+func importName(importConfig *latest.ImportConfig) string {
+	if importConfig.Git != "" {
+		if importConfig.Path != "" {
+			return importConfig.Git + ":" + importConfig.Path
+		}
+
+		return importConfig.Git
+	}
+
+	return importConfig.Path
+}
+
+// formatImportLocation returns the file and line of the import with the given index, e.g. devspace.yaml:12
+func formatImportLocation(configPath string, content []byte, index int) string {
+	line := findImportLine(content, index)
+	if line == 0 {
+		return configPath
+	}
+
+	return fmt.Sprintf("%s:%d", configPath, line)
+}
+
+// findImportLine returns the line number of the import with the given index or 0 if it cannot be found
+func findImportLine(content []byte, index int) int {
+	var (
+		scanner   = bufio.NewScanner(bytes.NewReader(content))
+		lineNr    = 0
+		inImports = false
+		indent    = -1
+		item      = -1
+	)
+
+	for scanner.Scan() {
+		lineNr++
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if !inImports {
+			inImports = importsKeyRegEx.MatchString(line)
+			continue
+		}
+
+		match := listItemRegEx.FindStringSubmatch(line)
+		if match == nil {
+			// The imports section ends with the next top level key
+			if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+				return 0
+			}
+
+			continue
+		}
+
+		if indent == -1 {
+			indent = len(match[1])
+		}
+		if len(match[1]) == indent {
+			item++
+			if item == index {
+				return lineNr
+			}
+		}
+	}
+
+	return 0
+}
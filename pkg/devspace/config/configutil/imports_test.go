@@ -0,0 +1,187 @@
+package configutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+type importsTestCase struct {
+	name string
+
+	files map[string]string
+
+	expected      string
+	expectedError string
+}
+
+func TestGetRawConfigWithImports(t *testing.T) {
+	testCases := []*importsTestCase{
+		{
+			name: "Merge imports",
+			files: map[string]string{
+				"devspace.yaml": `
+version: v1beta4
+imports:
+- path: images.yaml
+- path: deployments/devspace.yaml
+images:
+  backend:
+    image: john/backend
+deployments:
+- name: backend
+  helm:
+    componentChart: true
+`,
+				"images.yaml": `
+version: v1beta4
+images:
+  backend:
+    image: john/imported
+    tags:
+    - latest
+  frontend:
+    image: john/frontend
+`,
+				"deployments/devspace.yaml": `
+imports:
+- path: ../common.yaml
+deployments:
+- name: backend
+  helm:
+    chart:
+      name: imported
+- name: frontend
+  helm:
+    componentChart: true
+`,
+				"common.yaml": `
+commands:
+- name: test
+  command: go test ./...
+`,
+			},
+			expected: `
+version: v1beta4
+images:
+  backend:
+    image: john/backend
+    tags:
+    - latest
+  frontend:
+    image: john/frontend
+deployments:
+- name: frontend
+  helm:
+    componentChart: true
+- name: backend
+  helm:
+    componentChart: true
+commands:
+- name: test
+  command: go test ./...
+`,
+		},
+		{
+			name: "Cyclic import",
+			files: map[string]string{
+				"devspace.yaml": `
+version: v1beta4
+imports:
+- path: a.yaml
+`,
+				"a.yaml": `
+imports:
+- path: devspace.yaml
+`,
+			},
+			expectedError: "Cyclic import found",
+		},
+		{
+			name: "Missing import",
+			files: map[string]string{
+				"devspace.yaml": `
+version: v1beta4
+# Imported files
+imports:
+- path: a.yaml
+- path: missing.yaml
+`,
+				"a.yaml": `
+images: {}
+`,
+			},
+			expectedError: "devspace.yaml:6: import missing.yaml",
+		},
+		{
+			name: "Version mismatch",
+			files: map[string]string{
+				"devspace.yaml": `
+version: v1beta4
+imports:
+- path: a.yaml
+`,
+				"a.yaml": `
+version: v1beta3
+`,
+			},
+			expectedError: "Imported files have to use the same version",
+		},
+	}
+
+	for _, testCase := range testCases {
+		dir, err := ioutil.TempDir("", "test")
+		assert.NilError(t, err, "Error creating temp dir in testCase %s", testCase.name)
+		defer os.RemoveAll(dir)
+
+		for path, content := range testCase.files {
+			path = filepath.Join(dir, filepath.FromSlash(path))
+			err = os.MkdirAll(filepath.Dir(path), 0755)
+			assert.NilError(t, err, "Error creating dir in testCase %s", testCase.name)
+			err = ioutil.WriteFile(path, []byte(content), 0644)
+			assert.NilError(t, err, "Error writing file in testCase %s", testCase.name)
+		}
+
+		rawMap, err := GetRawConfigWithImports(filepath.Join(dir, "devspace.yaml"))
+		if testCase.expectedError != "" {
+			assert.Assert(t, err != nil, "No error in testCase %s", testCase.name)
+			assert.Assert(t, strings.Contains(err.Error(), testCase.expectedError), "Unexpected error %v in testCase %s", err, testCase.name)
+			continue
+		}
+		assert.NilError(t, err, "Error loading config in testCase %s", testCase.name)
+
+		expected := map[interface{}]interface{}{}
+		err = yaml.Unmarshal([]byte(testCase.expected), &expected)
+		assert.NilError(t, err, "Error parsing expected config in testCase %s", testCase.name)
+		assert.DeepEqual(t, rawMap, expected)
+	}
+}
+
+func TestRestoreVarsWithImports(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err, "Error creating temp dir")
+	defer os.RemoveAll(dir)
+
+	wdBackup, err := os.Getwd()
+	assert.NilError(t, err, "Error getting working directory")
+	err = os.Chdir(dir)
+	assert.NilError(t, err, "Error changing working directory")
+	defer os.Chdir(wdBackup)
+
+	err = ioutil.WriteFile("devspace.yaml", []byte(`
+version: v1beta4
+imports:
+- path: a.yaml
+`), 0644)
+	assert.NilError(t, err, "Error writing devspace.yaml")
+
+	_, err = RestoreVars(&latest.Config{Version: latest.Version})
+	assert.Error(t, err, "devspace.yaml imports other config files and cannot be changed automatically. Please edit devspace.yaml or the imported files manually")
+}
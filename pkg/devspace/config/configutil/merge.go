@@ -0,0 +1,105 @@
+package configutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// mergeKeys defines by which key the items of a list in the config are matched when merging
+var mergeKeys = map[string]string{
+	"deployments": "name",
+	"dev.sync":    "containerPath",
+}
+
+// ApplyMerge deep merges the merge section of the profile into the config. Lists with a natural key (e.g. deployments
+// by name) are merged item by item, other lists are replaced. A null value removes the key from the config
+func ApplyMerge(config map[interface{}]interface{}, profile map[interface{}]interface{}) error {
+	if profile == nil || profile["merge"] == nil {
+		return nil
+	}
+
+	mergeMap, ok := profile["merge"].(map[interface{}]interface{})
+	if !ok {
+		return errors.Errorf("profiles.%v.merge is not an object", profile["name"])
+	}
+
+	mergeMaps(config, mergeMap, "")
+	return nil
+}
+
+func mergeMaps(dst, src map[interface{}]interface{}, path string) {
+	for key, srcValue := range src {
+		keyPath := fmt.Sprintf("%v", key)
+		if path != "" {
+			keyPath = path + "." + keyPath
+		}
+
+		if srcValue == nil {
+			delete(dst, key)
+			continue
+		}
+
+		dst[key] = mergeValues(dst[key], srcValue, keyPath)
+	}
+}
+
+func mergeValues(dst, src interface{}, path string) interface{} {
+	switch srcValue := src.(type) {
+	case map[interface{}]interface{}:
+		dstMap, ok := dst.(map[interface{}]interface{})
+		if !ok {
+			return srcValue
+		}
+
+		mergeMaps(dstMap, srcValue, path)
+		return dstMap
+	case []interface{}:
+		dstArray, ok := dst.([]interface{})
+		mergeKey, hasMergeKey := mergeKeys[path]
+		if !ok || !hasMergeKey {
+			return srcValue
+		}
+
+		return mergeLists(dstArray, srcValue, mergeKey, path)
+	}
+
+	return src
+}
+
+// mergeLists merges the items of src into the items of dst with the same key and appends all other items
+func mergeLists(dst, src []interface{}, mergeKey, path string) []interface{} {
+	for _, srcItem := range src {
+		srcMap, ok := srcItem.(map[interface{}]interface{})
+		if !ok || srcMap[mergeKey] == nil {
+			dst = append(dst, srcItem)
+			continue
+		}
+
+		found := false
+		for idx, dstItem := range dst {
+			dstMap, ok := dstItem.(map[interface{}]interface{})
+			if ok && isSameKey(dstMap[mergeKey], srcMap[mergeKey]) {
+				mergeMaps(dstMap, srcMap, path+"[*]")
+				dst[idx] = dstMap
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			dst = append(dst, srcItem)
+		}
+	}
+
+	return dst
+}
+
+func isSameKey(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return false
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%v", a)) == strings.TrimSpace(fmt.Sprintf("%v", b))
+}
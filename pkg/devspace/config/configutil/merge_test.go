@@ -0,0 +1,129 @@
+package configutil
+
+import (
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+type mergeTestCase struct {
+	name string
+
+	config  string
+	profile string
+
+	expected string
+}
+
+func TestApplyMerge(t *testing.T) {
+	testCases := []*mergeTestCase{
+		{
+			name: "Merge maps and lists by natural key",
+			config: `
+images:
+  api:
+    image: api
+    tags:
+    - latest
+deployments:
+- name: db
+  helm:
+    chart:
+      name: mysql
+- name: api
+  helm:
+    values:
+      replicas: 1
+      debug: true
+dev:
+  sync:
+  - containerPath: /app
+    localSubPath: ./src
+`,
+			profile: `
+name: test
+merge:
+  images:
+    api:
+      tags:
+      - staging
+  deployments:
+  - name: api
+    helm:
+      values:
+        replicas: 3
+        debug: null
+  - name: cache
+    helm:
+      chart:
+        name: redis
+  dev:
+    sync:
+    - containerPath: /app
+      excludePaths:
+      - node_modules
+`,
+			expected: `
+images:
+  api:
+    image: api
+    tags:
+    - staging
+deployments:
+- name: db
+  helm:
+    chart:
+      name: mysql
+- name: api
+  helm:
+    values:
+      replicas: 3
+- name: cache
+  helm:
+    chart:
+      name: redis
+dev:
+  sync:
+  - containerPath: /app
+    localSubPath: ./src
+    excludePaths:
+    - node_modules
+`,
+		},
+		{
+			name: "No merge",
+			config: `
+images:
+  api:
+    image: api
+`,
+			profile: `
+name: test
+`,
+			expected: `
+images:
+  api:
+    image: api
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		config := map[interface{}]interface{}{}
+		err := yaml.Unmarshal([]byte(testCase.config), &config)
+		assert.NilError(t, err, "Error parsing config in testCase %s", testCase.name)
+
+		profile := map[interface{}]interface{}{}
+		err = yaml.Unmarshal([]byte(testCase.profile), &profile)
+		assert.NilError(t, err, "Error parsing profile in testCase %s", testCase.name)
+
+		expected := map[interface{}]interface{}{}
+		err = yaml.Unmarshal([]byte(testCase.expected), &expected)
+		assert.NilError(t, err, "Error parsing expected config in testCase %s", testCase.name)
+
+		err = ApplyMerge(config, profile)
+		assert.NilError(t, err, "Error merging in testCase %s", testCase.name)
+		assert.DeepEqual(t, config, expected)
+	}
+}
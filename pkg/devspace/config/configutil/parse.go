@@ -1,7 +1,6 @@
 package configutil
 
 import (
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -15,7 +14,6 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/util/survey"
 	varspkg "github.com/devspace-cloud/devspace/pkg/util/vars"
 	"github.com/pkg/errors"
-	yaml "gopkg.in/yaml.v2"
 )
 
 // LoadedVars holds all variables that were loaded
@@ -27,17 +25,11 @@ func varMatchFn(path, key, value string) bool {
 
 // GetProfiles retrieves all available profiles
 func GetProfiles(basePath string) ([]string, error) {
-	bytes, err := ioutil.ReadFile(filepath.Join(basePath, constants.DefaultConfigPath))
+	rawMap, err := GetRawConfigWithImports(filepath.Join(basePath, constants.DefaultConfigPath))
 	if err != nil {
 		return nil, err
 	}
 
-	rawMap := map[interface{}]interface{}{}
-	err = yaml.Unmarshal(bytes, &rawMap)
-	if err != nil {
-		return nil, errors.Errorf("Error parsing devspace.yaml: %v", err)
-	}
-
 	profiles, ok := rawMap["profiles"].([]interface{})
 	if !ok {
 		profiles = []interface{}{}
@@ -102,8 +94,8 @@ func ParseConfig(generatedConfig *generated.Config, data map[interface{}]interfa
 		return nil, err
 	}
 
-	// Get profile
-	profile, err := versions.ParseProfile(data, options.Profile)
+	// Get profiles
+	profiles, err := getProfiles(data, options.Profile)
 	if err != nil {
 		return nil, err
 	}
@@ -113,23 +105,35 @@ func ParseConfig(generatedConfig *generated.Config, data map[interface{}]interfa
 	delete(data, "profiles")
 	delete(data, "commands")
 
-	// Apply profile
-	if profile != nil {
+	// Apply profiles, parents are applied before their children
+	for _, profile := range profiles {
 		// Apply replace
-		err = ApplyReplace(data, profile)
+		err = ApplyReplace(data, profile.raw)
+		if err != nil {
+			return nil, err
+		}
+
+		// Apply merge
+		err = ApplyMerge(data, profile.raw)
 		if err != nil {
 			return nil, err
 		}
 
 		// Apply patches
-		data, err = ApplyPatches(data, profile)
+		data, err = ApplyPatches(data, profile.raw)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	// Apply overrides (e.g. from a parent project that uses this config as dependency)
+	data, err = applyPatchConfigs(data, options.Overrides, "overrides")
+	if err != nil {
+		return nil, err
+	}
+
 	// Fill in variables
-	err = FillVariables(generatedConfig, data, vars, options, log)
+	cmdVars, err := fillVariables(generatedConfig, data, vars, options, log)
 	if err != nil {
 		return nil, err
 	}
@@ -140,11 +144,20 @@ func ParseConfig(generatedConfig *generated.Config, data map[interface{}]interfa
 		return nil, errors.Wrap(err, "convert config")
 	}
 
+	// Remember the variables, so that templated manifests of this config are filled the same way
+	setVarsContext(latestConfig, generatedConfig, vars, cmdVars, options)
 	return latestConfig, nil
 }
 
 // FillVariables fills in the given vars into the prepared config
 func FillVariables(generatedConfig *generated.Config, preparedConfig map[interface{}]interface{}, vars []*latest.Variable, options *ConfigOptions, log log.Logger) error {
+	_, err := fillVariables(generatedConfig, preparedConfig, vars, options, log)
+	return err
+}
+
+// fillVariables fills in the given vars into the prepared config and returns the values that were resolved
+// from the cli, commands, files, secret stores and sensitive questions, which are not cached in the generated config
+func fillVariables(generatedConfig *generated.Config, preparedConfig map[interface{}]interface{}, vars []*latest.Variable, options *ConfigOptions, log log.Logger) (map[string]string, error) {
 	// Find out what vars are really used
 	varsUsed := map[string]bool{}
 	err := walk.Walk(preparedConfig, varMatchFn, func(path, value string) (interface{}, error) {
@@ -156,13 +169,13 @@ func FillVariables(generatedConfig *generated.Config, preparedConfig map[interfa
 		return value, nil
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Parse cli --var's
 	cmdVars, err := parseVarsFromOptions(options)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Fill used defined variables
@@ -175,9 +188,9 @@ func FillVariables(generatedConfig *generated.Config, preparedConfig map[interfa
 		}
 
 		if len(newVars) > 0 {
-			err = askQuestions(generatedConfig, newVars, cmdVars, log)
+			err = askQuestions(generatedConfig, newVars, cmdVars, options, log)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		}
 	}
@@ -185,7 +198,7 @@ func FillVariables(generatedConfig *generated.Config, preparedConfig map[interfa
 	// Fill predefined vars
 	err = fillPredefinedVars(options)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Walk over data and fill in variables
@@ -193,10 +206,10 @@ func FillVariables(generatedConfig *generated.Config, preparedConfig map[interfa
 		return varReplaceFn(path, value, generatedConfig, cmdVars, options, log)
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return cmdVars, nil
 }
 
 func parseVarsFromOptions(options *ConfigOptions) (map[string]string, error) {
@@ -214,15 +227,31 @@ func parseVarsFromOptions(options *ConfigOptions) (map[string]string, error) {
 	return vars, nil
 }
 
-func askQuestions(generatedConfig *generated.Config, vars []*latest.Variable, cmdVars map[string]string, log log.Logger) error {
+func askQuestions(generatedConfig *generated.Config, vars []*latest.Variable, cmdVars map[string]string, options *ConfigOptions, log log.Logger) error {
 	for _, variable := range vars {
 		name := strings.TrimSpace(variable.Name)
 
+		// Sensitive values are never cached in the generated config
+		if variable.Sensitive {
+			delete(generatedConfig.Vars, name)
+		}
+
 		// Check if var is provided through cli
 		if _, ok := cmdVars[name]; ok {
 			continue
 		}
 
+		// Variables from commands, files and secret stores are resolved on every run
+		if variable.Source != nil && isValueSource(*variable.Source) {
+			value, err := resolveVariableSource(variable, options)
+			if err != nil {
+				return errors.Wrapf(err, "resolve variable %s", name)
+			}
+
+			cmdVars[name] = value
+			continue
+		}
+
 		isInEnv := os.Getenv(name) != ""
 		// Check if variable is defined to be env var (source: env) but not defined
 		if variable.Source != nil && *variable.Source == latest.VariableSourceEnv && isInEnv == false {
@@ -252,17 +281,37 @@ func askQuestions(generatedConfig *generated.Config, vars []*latest.Variable, cm
 		}
 
 		// Ask question
-		var err error
-
-		generatedConfig.Vars[name], err = askQuestion(variable, log)
+		answer, err := askQuestion(variable, log)
 		if err != nil {
 			return err
 		}
+
+		if variable.Sensitive {
+			cmdVars[name] = answer
+		} else {
+			generatedConfig.Vars[name] = answer
+		}
+	}
+
+	// Make sure sensitive values do not show up in the logs
+	for _, variable := range vars {
+		if variable.Sensitive {
+			name := strings.TrimSpace(variable.Name)
+			if value, ok := cmdVars[name]; ok {
+				maskValue(value)
+			} else {
+				maskValue(os.Getenv(name))
+			}
+		}
 	}
 
 	return nil
 }
 
+func maskValue(value string) {
+	log.AddSensitive(value)
+}
+
 func varReplaceFn(path, value string, generatedConfig *generated.Config, cmdVars map[string]string, options *ConfigOptions, log log.Logger) (interface{}, error) {
 	// Save old value
 	if options.LoadedVars != nil {
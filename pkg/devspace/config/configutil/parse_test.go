@@ -245,6 +245,54 @@ profiles:
 				},
 			},
 		},
+		{
+			in: &parseTestCaseInput{
+				config: `
+version: v1beta3
+deployments:
+- name: test
+  component:
+    containers:
+		- image: nginx
+profiles:
+- name: testprofile
+	patches:
+	- op: replace
+		path: deployments[0].component.containers[0].image
+		value: ubuntu`,
+				options: &ConfigOptions{
+					Profile: "testprofile",
+					Vars:    []string{"test_var=alpine"},
+					Overrides: []*latest.PatchConfig{
+						{
+							Operation: "replace",
+							Path:      "deployments[0].component.containers[0].image",
+							Value:     "${test_var}",
+						},
+					},
+				},
+				generatedConfig: &generated.Config{Vars: map[string]string{}},
+			},
+			expected: &latest.Config{
+				Version: latest.Version,
+				Dev:     &latest.DevConfig{},
+				Deployments: []*latest.DeploymentConfig{
+					{
+						Name: "test",
+						Helm: &latest.HelmConfig{
+							ComponentChart: ptr.Bool(true),
+							Values: map[interface{}]interface{}{
+								"containers": []interface{}{
+									map[interface{}]interface{}{
+										"image": "alpine",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	// Execute test cases
@@ -1,7 +1,7 @@
 package configutil
 
 import (
-	"regexp"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -15,11 +15,6 @@ import (
 
 // ApplyPatches applies the patches to the config if defined
 func ApplyPatches(data map[interface{}]interface{}, profile map[interface{}]interface{}) (map[interface{}]interface{}, error) {
-	out, err := yaml.Marshal(data)
-	if err != nil {
-		return nil, err
-	}
-
 	patchesRaw, ok := profile["patches"]
 	if !ok {
 		return data, nil
@@ -33,23 +28,47 @@ func ApplyPatches(data map[interface{}]interface{}, profile map[interface{}]inte
 	}
 
 	configPatches := []*latest.PatchConfig{}
-	err = util.Convert(patchesArr, &configPatches)
+	err := util.Convert(patchesArr, &configPatches)
 	if err != nil {
 		return nil, errors.Wrap(err, "convert patches")
 	}
 
+	return applyPatchConfigs(data, configPatches, fmt.Sprintf("profiles.%v.patches", profile["name"]))
+}
+
+// applyPatchConfigs applies the given patches to the config. The prefix is used for error messages
+func applyPatchConfigs(data map[interface{}]interface{}, configPatches []*latest.PatchConfig, prefix string) (map[interface{}]interface{}, error) {
+	if len(configPatches) == 0 {
+		return data, nil
+	}
+
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
 	patches := yamlpatch.Patch{}
 	for idx, patch := range configPatches {
 		if patch.Operation == "" {
-			return nil, errors.Errorf("profiles.%v.patches.%d.op is missing", profile["name"], idx)
+			return nil, errors.Errorf("%s.%d.op is missing", prefix, idx)
 		} else if patch.Path == "" {
-			return nil, errors.Errorf("profiles.%v.patches.%d.path is missing", profile["name"], idx)
+			return nil, errors.Errorf("%s.%d.path is missing", prefix, idx)
+		}
+
+		path, err := resolveSelectors(transformPath(patch.Path), data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s.%d.path", prefix, idx)
+		}
+
+		from, err := resolveSelectors(transformPath(patch.From), data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s.%d.from", prefix, idx)
 		}
 
 		newPatch := yamlpatch.Operation{
 			Op:   yamlpatch.Op(patch.Operation),
-			Path: yamlpatch.OpPath(transformPath(patch.Path)),
-			From: yamlpatch.OpPath(transformPath(patch.From)),
+			Path: yamlpatch.OpPath(path),
+			From: yamlpatch.OpPath(from),
 		}
 
 		if patch.Value != nil {
@@ -83,8 +102,6 @@ func ApplyPatches(data map[interface{}]interface{}, profile map[interface{}]inte
 	return newConfig, nil
 }
 
-var replaceArrayRegEx = regexp.MustCompile("\\[\\\"?([^\\]\\\"]+)\\\"?\\]")
-
 func findPath(path *yamlpatch.OpPath, c interface{}) (interface{}, error) {
 	parts, key, err := path.Decompose()
 	if err != nil {
@@ -114,7 +131,7 @@ func findPath(path *yamlpatch.OpPath, c interface{}) (interface{}, error) {
 			}
 
 			if i >= 0 && i <= len(iArray)-1 {
-				foundContainer = iMap[part]
+				foundContainer = iArray[i]
 				continue
 			}
 
@@ -127,16 +144,86 @@ func findPath(path *yamlpatch.OpPath, c interface{}) (interface{}, error) {
 	return foundContainer, nil
 }
 
+// resolveSelectors replaces list item selectors like name=api in the json pointer with the index of the matching item
+func resolveSelectors(path string, data interface{}) (string, error) {
+	if strings.Contains(path, "=") == false {
+		return path, nil
+	}
+
+	parts := strings.Split(path, "/")[1:]
+	current := data
+	for idx, part := range parts {
+		switch container := current.(type) {
+		case map[interface{}]interface{}:
+			current = container[strings.Replace(strings.Replace(part, "~1", "/", -1), "~0", "~", -1)]
+		case []interface{}:
+			if kv := strings.SplitN(part, "=", 2); len(kv) == 2 {
+				index, err := findArrayItem(container, kv[0], kv[1])
+				if err != nil {
+					return "", err
+				}
+
+				parts[idx] = strconv.Itoa(index)
+				current = container[index]
+			} else if i, err := strconv.Atoi(part); err == nil && i >= 0 && i < len(container) {
+				current = container[i]
+			} else {
+				current = nil
+			}
+		default:
+			if strings.Contains(part, "=") {
+				return "", errors.Errorf("Cannot select %s, because the value is not an array", part)
+			}
+
+			current = nil
+		}
+	}
+
+	return "/" + strings.Join(parts, "/"), nil
+}
+
+func findArrayItem(array []interface{}, key, value string) (int, error) {
+	for idx, item := range array {
+		itemMap, ok := item.(map[interface{}]interface{})
+		if ok && itemMap[key] != nil && fmt.Sprintf("%v", itemMap[key]) == value {
+			return idx, nil
+		}
+	}
+
+	return 0, errors.Errorf("Cannot find an item with %s=%s", key, value)
+}
+
+// transformPath converts a path like deployments[0].helm.values or deployments[name=api].helm.values into
+// a json pointer. Dots within brackets are not treated as separators, so images["my.image"] works as well
 func transformPath(path string) string {
 	// Test if XPath
 	if path == "" || path[0] == '/' {
 		return path
 	}
 
-	// Replace t[0] -> t/0
-	path = replaceArrayRegEx.ReplaceAllString(path, "/$1")
-	path = strings.Replace(path, ".", "/", -1)
-	path = "/" + path
+	var (
+		out        = &strings.Builder{}
+		inBrackets = false
+	)
+
+	out.WriteString("/")
+	for _, c := range path {
+		switch {
+		case c == '[' && !inBrackets:
+			inBrackets = true
+			out.WriteString("/")
+		case c == ']' && inBrackets:
+			inBrackets = false
+		case c == '"' && inBrackets:
+			// Quotes are only used to escape the key
+		case c == '.' && !inBrackets:
+			out.WriteString("/")
+		case c == '/':
+			out.WriteString("~1")
+		default:
+			out.WriteRune(c)
+		}
+	}
 
-	return path
+	return out.String()
 }
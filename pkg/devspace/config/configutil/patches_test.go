@@ -50,6 +50,58 @@ func TestPatches(t *testing.T) {
 				},
 			},
 		},
+		{
+			profile: map[interface{}]interface{}{
+				"name": "test",
+				"patches": []interface{}{
+					map[interface{}]interface{}{
+						"op":    "replace",
+						"path":  "deployments[name=api].helm.values.replicas",
+						"value": 3,
+					},
+				},
+			},
+			in: map[interface{}]interface{}{
+				"deployments": []interface{}{
+					map[interface{}]interface{}{
+						"name": "db",
+						"helm": map[interface{}]interface{}{
+							"values": map[interface{}]interface{}{
+								"replicas": 1,
+							},
+						},
+					},
+					map[interface{}]interface{}{
+						"name": "api",
+						"helm": map[interface{}]interface{}{
+							"values": map[interface{}]interface{}{
+								"replicas": 1,
+							},
+						},
+					},
+				},
+			},
+			expected: map[interface{}]interface{}{
+				"deployments": []interface{}{
+					map[interface{}]interface{}{
+						"name": "db",
+						"helm": map[interface{}]interface{}{
+							"values": map[interface{}]interface{}{
+								"replicas": 1,
+							},
+						},
+					},
+					map[interface{}]interface{}{
+						"name": "api",
+						"helm": map[interface{}]interface{}{
+							"values": map[interface{}]interface{}{
+								"replicas": 3,
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	// Run test cases
@@ -47,6 +47,18 @@ var PredefinedVars = map[string]*predefinedVarDefinition{
 			return ptr.String(hash[:8]), nil
 		},
 	},
+	"DEVSPACE_PROFILE": &predefinedVarDefinition{
+		ErrorMessage: fmt.Sprintf("No profile is active, but predefined var DEVSPACE_PROFILE is used.\n\nPlease run: \n- `%s` to select a profile\n- `%s` to list existing profiles", ansi.Color("devspace use profile [NAME]", "white+b"), ansi.Color("devspace list profiles", "white+b")),
+		Fill: func(options *ConfigOptions) (*string, error) {
+			// If multiple profiles are active, the last one is the most specific one (e.g. debug in 'staging,debug')
+			profiles := SplitProfiles(options.Profile)
+			if len(profiles) == 0 {
+				return nil, nil
+			}
+
+			return ptr.String(profiles[len(profiles)-1]), nil
+		},
+	},
 	"DEVSPACE_SPACE": &predefinedVarDefinition{
 		ErrorMessage: fmt.Sprintf("Current context is not a space, but predefined var DEVSPACE_SPACE is used.\n\nPlease run: \n- `%s` to create a new space\n- `%s` to use an existing space\n- `%s` to list existing spaces", ansi.Color("devspace create space [NAME]", "white+b"), ansi.Color("devspace use space [NAME]", "white+b"), ansi.Color("devspace list spaces", "white+b")),
 		Fill: func(options *ConfigOptions) (*string, error) {
@@ -0,0 +1,177 @@
+package configutil
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/util"
+	"github.com/pkg/errors"
+)
+
+// ProfileSeparator separates multiple profiles that are active at the same time, e.g. 'staging,debug'
+const ProfileSeparator = generated.ProfileSeparator
+
+// SplitProfiles returns the names of all profiles in a profile string like 'staging,debug'
+func SplitProfiles(profile string) []string {
+	profiles := []string{}
+	for _, name := range strings.Split(profile, ProfileSeparator) {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			profiles = append(profiles, name)
+		}
+	}
+
+	return profiles
+}
+
+// resolvedProfile is a profile that should be applied to the config
+type resolvedProfile struct {
+	name string
+	raw  map[interface{}]interface{}
+
+	// ancestors holds the names of all direct and indirect parents of the profile
+	ancestors map[string]bool
+}
+
+// getProfiles returns all profiles that have to be applied in the order they have to be applied. Parents are
+// always applied before their children and every profile is only applied once
+func getProfiles(data map[interface{}]interface{}, profile string) ([]*resolvedProfile, error) {
+	var (
+		resolved = []*resolvedProfile{}
+		byName   = map[string]*resolvedProfile{}
+	)
+
+	var resolve func(name string, path []string) (*resolvedProfile, error)
+	resolve = func(name string, path []string) (*resolvedProfile, error) {
+		for idx, visited := range path {
+			if visited == name {
+				return nil, errors.Errorf("Cyclic profile inheritance found: %s", strings.Join(append(path[idx:], name), " -> "))
+			}
+		}
+		if existing, ok := byName[name]; ok {
+			return existing, nil
+		}
+
+		raw, err := versions.ParseProfile(data, name)
+		if err != nil {
+			return nil, err
+		}
+
+		parents, err := getProfileParents(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		current := &resolvedProfile{
+			name:      name,
+			raw:       raw,
+			ancestors: map[string]bool{},
+		}
+		for _, parentName := range parents {
+			parent, err := resolve(parentName, append(append([]string{}, path...), name))
+			if err != nil {
+				return nil, err
+			}
+
+			current.ancestors[parent.name] = true
+			for ancestor := range parent.ancestors {
+				current.ancestors[ancestor] = true
+			}
+		}
+
+		byName[name] = current
+		resolved = append(resolved, current)
+		return current, nil
+	}
+
+	for _, name := range SplitProfiles(profile) {
+		_, err := resolve(name, []string{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := checkProfileConflicts(resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolved, nil
+}
+
+// getProfileParents returns the parents of a profile from the parent and parents option
+func getProfileParents(raw map[interface{}]interface{}) ([]string, error) {
+	profile := &latest.ProfileConfig{}
+	err := util.Convert(map[interface{}]interface{}{"parent": raw["parent"], "parents": raw["parents"]}, profile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "profiles.%v.parents", raw["name"])
+	}
+
+	parents := []string{}
+	if profile.Parent != "" {
+		parents = append(parents, profile.Parent)
+	}
+
+	return append(parents, profile.Parents...), nil
+}
+
+// checkProfileConflicts makes sure that profiles which do not inherit from each other do not change the same
+// part of the config differently, because the result would depend on the order of the profiles
+func checkProfileConflicts(profiles []*resolvedProfile) error {
+	type change struct {
+		profile string
+		value   interface{}
+	}
+
+	replaced := map[string]*change{}
+	patched := map[string]*change{}
+	for _, profile := range profiles {
+		if replaceMap, ok := profile.raw["replace"].(map[interface{}]interface{}); ok {
+			for key, value := range replaceMap {
+				section, _ := key.(string)
+				if other, ok := replaced[section]; ok && isRelated(profiles, profile, other.profile) == false && reflect.DeepEqual(other.value, value) == false {
+					return errors.Errorf("Profiles %s and %s both replace '%s' with different values. Please only use one of them or make one profile the parent of the other", other.profile, profile.name, section)
+				}
+
+				replaced[section] = &change{profile: profile.name, value: value}
+			}
+		}
+
+		if patches, ok := profile.raw["patches"].([]interface{}); ok {
+			for _, patch := range patches {
+				patchMap, ok := patch.(map[interface{}]interface{})
+				if !ok || patchMap["op"] != "replace" {
+					continue
+				}
+
+				path, _ := patchMap["path"].(string)
+				path = transformPath(path)
+				if other, ok := patched[path]; ok && isRelated(profiles, profile, other.profile) == false && reflect.DeepEqual(other.value, patchMap["value"]) == false {
+					return errors.Errorf("Profiles %s and %s both patch '%s' with different values. Please only use one of them or make one profile the parent of the other", other.profile, profile.name, patchMap["path"])
+				}
+
+				patched[path] = &change{profile: profile.name, value: patchMap["value"]}
+			}
+		}
+	}
+
+	return nil
+}
+
+// isRelated checks if one of the profiles inherits from the other
+func isRelated(profiles []*resolvedProfile, profile *resolvedProfile, otherName string) bool {
+	if profile.ancestors[otherName] {
+		return true
+	}
+
+	for _, other := range profiles {
+		if other.name == otherName {
+			return other.ancestors[profile.name]
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,133 @@
+package configutil
+
+import (
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+type getProfilesTestCase struct {
+	name string
+
+	config  string
+	profile string
+
+	expectedProfiles []string
+	expectedErr      string
+}
+
+func TestGetProfiles(t *testing.T) {
+	config := `
+profiles:
+- name: base
+  patches:
+  - op: replace
+    path: images.default.image
+    value: base
+- name: staging
+  parent: base
+  patches:
+  - op: replace
+    path: images.default.image
+    value: staging
+- name: debug
+  parents:
+  - staging
+  - tools
+- name: tools
+  replace:
+    commands: []
+- name: other
+  replace:
+    commands:
+    - name: test
+- name: same-value
+  patches:
+  - op: replace
+    path: images.default.image
+    value: staging
+- name: cyclic-a
+  parent: cyclic-b
+- name: cyclic-b
+  parent: cyclic-a
+`
+
+	testCases := []*getProfilesTestCase{
+		{
+			name:             "No profile",
+			expectedProfiles: []string{},
+		},
+		{
+			name:             "Parents are applied first",
+			profile:          "debug",
+			expectedProfiles: []string{"base", "staging", "tools", "debug"},
+		},
+		{
+			name:             "Multiple profiles are applied in order and only once",
+			profile:          "staging,debug",
+			expectedProfiles: []string{"base", "staging", "tools", "debug"},
+		},
+		{
+			name:             "Same values do not conflict",
+			profile:          "staging,same-value",
+			expectedProfiles: []string{"base", "staging", "same-value"},
+		},
+		{
+			name:        "Conflicting patches",
+			profile:     "base,same-value",
+			expectedErr: "Profiles base and same-value both patch 'images.default.image' with different values. Please only use one of them or make one profile the parent of the other",
+		},
+		{
+			name:        "Conflicting replace",
+			profile:     "debug,other",
+			expectedErr: "Profiles tools and other both replace 'commands' with different values. Please only use one of them or make one profile the parent of the other",
+		},
+		{
+			name:        "Cyclic inheritance",
+			profile:     "cyclic-a",
+			expectedErr: "Cyclic profile inheritance found: cyclic-a -> cyclic-b -> cyclic-a",
+		},
+		{
+			name:        "Unknown profile",
+			profile:     "doesnotexist",
+			expectedErr: "Couldn't find profile 'doesnotexist'",
+		},
+	}
+
+	data := map[interface{}]interface{}{}
+	err := yaml.Unmarshal([]byte(config), &data)
+	assert.NilError(t, err)
+
+	for _, testCase := range testCases {
+		profiles, err := getProfiles(data, testCase.profile)
+		if testCase.expectedErr != "" {
+			assert.Error(t, err, testCase.expectedErr, "Wrong error in testCase %s", testCase.name)
+			continue
+		}
+		assert.NilError(t, err, "Unexpected error in testCase %s", testCase.name)
+
+		names := []string{}
+		for _, profile := range profiles {
+			names = append(names, profile.name)
+		}
+		assert.Equal(t, strings.Join(names, ","), strings.Join(testCase.expectedProfiles, ","), "Wrong profiles in testCase %s", testCase.name)
+	}
+}
+
+func TestProfilePredefinedVar(t *testing.T) {
+	for profile, expected := range map[string]string{
+		"staging":         "staging",
+		"staging,debug":   "debug",
+		"staging, debug ": "debug",
+	} {
+		value, err := PredefinedVars["DEVSPACE_PROFILE"].Fill(&ConfigOptions{Profile: profile})
+		assert.NilError(t, err)
+		assert.Equal(t, *value, expected, "Wrong DEVSPACE_PROFILE for profile %s", profile)
+	}
+
+	value, err := PredefinedVars["DEVSPACE_PROFILE"].Fill(&ConfigOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, value == nil, "DEVSPACE_PROFILE is set without active profile")
+}
@@ -53,6 +53,11 @@ func RestoreVars(config *latest.Config) (*latest.Config, error) {
 			return nil, err
 		}
 
+		// The loaded config contains the content of all imported files, which we cannot split up again
+		if _, ok := originalConfig["imports"]; ok {
+			return nil, errors.Errorf("%s imports other config files and cannot be changed automatically. Please edit %s or the imported files manually", constants.DefaultConfigPath, constants.DefaultConfigPath)
+		}
+
 		// Now merge missing from original into new
 		for key := range originalConfig {
 			if _, ok := configMap[key]; !ok {
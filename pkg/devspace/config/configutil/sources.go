@@ -0,0 +1,93 @@
+package configutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/command"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/secrets"
+
+	"github.com/pkg/errors"
+)
+
+// isValueSource checks if the value of a variable with the given source is retrieved without asking the user
+func isValueSource(source latest.VariableSource) bool {
+	return source == latest.VariableSourceCommand || source == latest.VariableSourceFile || source == latest.VariableSourceSecret
+}
+
+// resolveVariableSource retrieves the value of a variable from a command, file or secret store
+func resolveVariableSource(variable *latest.Variable, options *ConfigOptions) (string, error) {
+	basePath := options.BasePath
+	if basePath == "" {
+		var err error
+		basePath, err = os.Getwd()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var (
+		value string
+		err   error
+	)
+	switch *variable.Source {
+	case latest.VariableSourceCommand:
+		if variable.Command == "" {
+			return "", errors.New("command is missing")
+		}
+
+		value, err = command.OutputCommand(variable.Command, basePath)
+		if err != nil {
+			return "", errors.Wrapf(err, "run command %s", variable.Command)
+		}
+	case latest.VariableSourceFile:
+		if variable.File == "" {
+			return "", errors.New("file is missing")
+		}
+
+		filePath := variable.File
+		if filepath.IsAbs(filePath) == false {
+			filePath = filepath.Join(basePath, filepath.FromSlash(filePath))
+		}
+
+		out, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return "", err
+		}
+
+		value = string(out)
+	case latest.VariableSourceSecret:
+		secret := variable.Secret
+		if secret == nil {
+			secret = &latest.VariableSecret{}
+		}
+
+		key := secret.Key
+		if key == "" {
+			key = strings.TrimSpace(variable.Name)
+		}
+
+		store, err := secrets.NewStore(secret.Store, secret.Path, basePath)
+		if err != nil {
+			return "", err
+		}
+
+		value, err = store.Get(key)
+		if err != nil {
+			return "", err
+		}
+	default:
+		return "", errors.Errorf("Unsupported variable source %s", *variable.Source)
+	}
+
+	// Commands and files usually end with a newline
+	value = strings.TrimRight(value, "\r\n")
+	if value == "" && variable.Default != "" {
+		return variable.Default, nil
+	}
+
+	return value, nil
+}
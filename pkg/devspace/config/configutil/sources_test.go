@@ -0,0 +1,65 @@
+package configutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+func TestVariableSources(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testVariableSources")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "image.txt"), []byte("nginx\n"), 0644)
+	assert.NilError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "secrets.yaml"), []byte("token: my-secret-token\n"), 0600)
+	assert.NilError(t, err)
+
+	config := `
+version: ` + latest.Version + `
+deployments:
+- name: ${name}
+  kubectl:
+    manifests:
+    - ${image}
+    - ${token}
+vars:
+- name: name
+  source: command
+  command: printf 'my-%s' deployment
+- name: image
+  source: file
+  file: image.txt
+- name: token
+  source: secret
+  sensitive: true
+  secret:
+    path: secrets.yaml
+`
+
+	testMap := map[interface{}]interface{}{}
+	err = yaml.Unmarshal([]byte(config), &testMap)
+	assert.NilError(t, err)
+
+	// The cached value of the sensitive variable should be removed
+	generatedConfig := &generated.Config{Vars: map[string]string{"token": "cached"}}
+	newConfig, err := ParseConfig(generatedConfig, testMap, &ConfigOptions{BasePath: dir}, log.Discard)
+	assert.NilError(t, err)
+
+	assert.Equal(t, newConfig.Deployments[0].Name, "my-deployment")
+	assert.DeepEqual(t, newConfig.Deployments[0].Kubectl.Manifests, []string{"nginx", "my-secret-token"})
+	assert.DeepEqual(t, generatedConfig.Vars, map[string]string{})
+	assert.Equal(t, strings.Contains(log.Mask("token is my-secret-token"), "my-secret-token"), false)
+}
@@ -0,0 +1,121 @@
+package configutil
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	varspkg "github.com/devspace-cloud/devspace/pkg/util/vars"
+	"github.com/pkg/errors"
+)
+
+// varsContext holds everything that is needed to resolve variables the same way as in the config they belong to
+type varsContext struct {
+	mutex sync.Mutex
+
+	generatedConfig *generated.Config
+	vars            []*latest.Variable
+	cmdVars         map[string]string
+	options         *ConfigOptions
+	predefinedVars  map[string]string
+}
+
+var varsContextsMutex sync.Mutex
+
+// varsContexts holds the variable context of every config that was parsed. Dependencies use their own context
+var varsContexts = map[*latest.Config]*varsContext{}
+
+// setVarsContext remembers the variables and options the config was parsed with
+func setVarsContext(config *latest.Config, generatedConfig *generated.Config, vars []*latest.Variable, cmdVars map[string]string, options *ConfigOptions) {
+	predefinedVars := map[string]string{}
+	for name, predefinedVariable := range PredefinedVars {
+		if predefinedVariable.Value != nil {
+			predefinedVars[name] = *predefinedVariable.Value
+		}
+	}
+
+	varsContextsMutex.Lock()
+	defer varsContextsMutex.Unlock()
+
+	varsContexts[config] = &varsContext{
+		generatedConfig: generatedConfig,
+		vars:            vars,
+		cmdVars:         cmdVars,
+		options:         options,
+		predefinedVars:  predefinedVars,
+	}
+}
+
+// getVarsContext returns the variable context of the config. Configs that were not parsed (e.g. fake configs) have
+// no context, because their variables, options and cache are unknown
+func getVarsContext(config *latest.Config) (*varsContext, error) {
+	varsContextsMutex.Lock()
+	defer varsContextsMutex.Unlock()
+
+	if ctx, ok := varsContexts[config]; ok {
+		return ctx, nil
+	}
+
+	return nil, errors.New("Cannot replace variables, because the config was not parsed")
+}
+
+// ReplaceVars replaces all variables in the given text the same way as they are replaced in the given config, which is
+// the config that defines the text (e.g. the config of a dependency). Variables in extraVars take precedence over all other variable sources
+func ReplaceVars(config *latest.Config, text string, extraVars map[string]string, log log.Logger) (string, error) {
+	ctx, err := getVarsContext(config)
+	if err != nil {
+		return "", err
+	}
+
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+
+	// Values from the cli, sources and sensitive questions are shared with the config, so they are not asked
+	// again and sensitive values are never written to the generated config
+	cmdVars := ctx.cmdVars
+
+	// Find out what defined vars are used
+	varsUsed := map[string]bool{}
+	varspkg.ParseString(text, func(v string) (string, error) {
+		varsUsed[v] = true
+		return "", nil
+	})
+
+	// Fill used defined variables like in the config, so defaults, questions and sources are respected
+	newVars := []*latest.Variable{}
+	for _, variable := range ctx.vars {
+		name := strings.TrimSpace(variable.Name)
+		if _, ok := extraVars[name]; ok == false && varsUsed[name] {
+			newVars = append(newVars, variable)
+		}
+	}
+	if len(newVars) > 0 {
+		err = askQuestions(ctx.generatedConfig, newVars, cmdVars, ctx.options, log)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	replaced, err := varspkg.ParseString(text, func(v string) (string, error) {
+		if val, ok := extraVars[v]; ok {
+			return val, nil
+		}
+
+		// Predefined vars have the values they had when the config was loaded
+		if _, ok := cmdVars[v]; ok == false {
+			if val, ok := ctx.predefinedVars[strings.ToUpper(v)]; ok {
+				return val, nil
+			}
+		}
+
+		return resolveVar(v, ctx.generatedConfig, cmdVars, ctx.options, log)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%v", replaced), nil
+}
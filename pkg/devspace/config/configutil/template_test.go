@@ -0,0 +1,87 @@
+package configutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+func TestReplaceVars(t *testing.T) {
+	config := `
+version: ` + latest.Version + `
+vars:
+- name: TEMPLATE_TEST_DEFAULT
+  source: env
+  default: my-default
+- name: TEMPLATE_TEST_CACHED
+`
+
+	testMap := map[interface{}]interface{}{}
+	err := yaml.Unmarshal([]byte(config), &testMap)
+	assert.NilError(t, err)
+
+	generatedConfig := &generated.Config{Vars: map[string]string{"TEMPLATE_TEST_CACHED": "cached"}}
+	options := &ConfigOptions{Vars: []string{"TEMPLATE_TEST_CLI=cli"}}
+	newConfig, err := ParseConfig(generatedConfig, testMap, options, log.Discard)
+	assert.NilError(t, err)
+	defer os.Unsetenv("TEMPLATE_TEST_DEFAULT")
+
+	// Variables are resolved with the vars and options of the config that defines the template
+	replaced, err := ReplaceVars(newConfig, "${TEMPLATE_TEST_DEFAULT} ${TEMPLATE_TEST_CACHED} ${TEMPLATE_TEST_CLI} ${TEMPLATE_TEST_EXTRA}", map[string]string{"TEMPLATE_TEST_EXTRA": "extra"}, log.Discard)
+	assert.NilError(t, err)
+	assert.Equal(t, replaced, "my-default cached cli extra")
+
+	// Configs that were not parsed have no variables to resolve
+	_, err = ReplaceVars(&latest.Config{}, "${TEMPLATE_TEST_DEFAULT}", nil, log.Discard)
+	assert.Error(t, err, "Cannot replace variables, because the config was not parsed")
+}
+
+func TestReplaceVarsSensitive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testReplaceVarsSensitive")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := `
+version: ` + latest.Version + `
+deployments:
+- name: ${TEMPLATE_TEST_SECRET}
+vars:
+- name: TEMPLATE_TEST_SECRET
+  source: command
+  command: echo run >> runs.txt && printf my-template-secret
+  sensitive: true
+`
+
+	testMap := map[interface{}]interface{}{}
+	err = yaml.Unmarshal([]byte(config), &testMap)
+	assert.NilError(t, err)
+
+	generatedConfig := &generated.Config{Vars: map[string]string{}}
+	newConfig, err := ParseConfig(generatedConfig, testMap, &ConfigOptions{BasePath: dir}, log.Discard)
+	assert.NilError(t, err)
+
+	// The value resolved while loading the config is reused and never cached
+	replaced, err := ReplaceVars(newConfig, "${TEMPLATE_TEST_SECRET}", nil, log.Discard)
+	assert.NilError(t, err)
+	assert.Equal(t, replaced, "my-template-secret")
+	assert.DeepEqual(t, generatedConfig.Vars, map[string]string{})
+
+	runs, err := ioutil.ReadFile(filepath.Join(dir, "runs.txt"))
+	assert.NilError(t, err)
+	assert.Equal(t, strings.Count(string(runs), "run"), 1, "Command was executed more than once")
+
+	// Very short values are not masked
+	log.AddSensitive("ab")
+	assert.Equal(t, log.Mask("abc my-template-secret"), "abc "+log.MaskedValue)
+}
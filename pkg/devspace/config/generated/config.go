@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	yaml "gopkg.in/yaml.v2"
@@ -57,6 +58,9 @@ type DeploymentCache struct {
 // ConfigPath is the relative generated config path
 var ConfigPath = ".devspace/generated.yaml"
 
+// ProfileSeparator separates multiple profiles that are active at the same time, e.g. 'staging,debug'
+const ProfileSeparator = ","
+
 var loadedConfig *Config
 var loadedConfigErr error
 var loadedConfigOnce sync.Once
@@ -152,12 +156,26 @@ func (config *Config) GetActiveProfile() string {
 
 // GetActive returns the currently active devspace config
 func (config *Config) GetActive() *CacheConfig {
-	active := config.GetActiveProfile()
+	active := cacheKey(config.GetActiveProfile())
 
 	InitDevSpaceConfig(config, active)
 	return config.Profiles[active]
 }
 
+// cacheKey returns the key of the cache for the given profiles. The order of multiple profiles is kept, because
+// profiles that merge or add to the same part of the config result in different configs when they are reordered
+func cacheKey(profile string) string {
+	profiles := []string{}
+	for _, name := range strings.Split(profile, ProfileSeparator) {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			profiles = append(profiles, name)
+		}
+	}
+
+	return strings.Join(profiles, ProfileSeparator)
+}
+
 // GetImageCache returns the image cache if it exists and creates one if not
 func (cache *CacheConfig) GetImageCache(imageConfigName string) *ImageCache {
 	if _, ok := cache.Images[imageConfigName]; !ok {
@@ -196,6 +214,12 @@ func InitDevSpaceConfig(config *Config, configName string) {
 
 // SaveConfig saves the config to the filesystem
 func SaveConfig(config *Config) error {
+	workdir, _ := os.Getwd()
+	return SaveConfigToPath(config, filepath.Join(workdir, ConfigPath))
+}
+
+// SaveConfigToPath saves the config to the given path
+func SaveConfigToPath(config *Config, configPath string) error {
 	loadedConfigMutex.Lock()
 	defer loadedConfigMutex.Unlock()
 
@@ -203,13 +227,11 @@ func SaveConfig(config *Config) error {
 		return nil
 	}
 
-	workdir, _ := os.Getwd()
 	data, err := yaml.Marshal(config)
 	if err != nil {
 		return err
 	}
 
-	configPath := filepath.Join(workdir, ConfigPath)
 	err = os.MkdirAll(filepath.Dir(configPath), 0755)
 	if err != nil {
 		return err
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 
 	"gotest.tools/assert"
 )
@@ -154,3 +155,22 @@ func TestGetCaches(t *testing.T) {
 	assert.Equal(t, "", deploymentCache.HelmChartHash, "DeploymentCache wrong initialized")
 	assert.Equal(t, "", deploymentCache.KubectlManifestsHash, "DeploymentCache wrong initialized")
 }
+
+func TestGetActiveWithMultipleProfiles(t *testing.T) {
+	dsConfig := &Config{
+		Profiles: map[string]*CacheConfig{},
+	}
+
+	dsConfig.OverrideProfile = ptr.String("staging,debug")
+	dsConfig.GetActive().GetImageCache("image").Tag = "abc"
+
+	// Whitespace does not change the cache
+	dsConfig.OverrideProfile = ptr.String(" staging , debug")
+	assert.Equal(t, "abc", dsConfig.GetActive().GetImageCache("image").Tag, "Wrong cache for profiles with whitespace")
+	assert.Equal(t, 1, len(dsConfig.Profiles), "Profiles with whitespace created a new cache")
+
+	// Reordered profiles can result in a different config, so they use their own cache
+	dsConfig.OverrideProfile = ptr.String("debug,staging")
+	assert.Equal(t, "", dsConfig.GetActive().GetImageCache("image").Tag, "Reordered profiles share the cache")
+	assert.Equal(t, 2, len(dsConfig.Profiles), "Reordered profiles did not create a new cache")
+}
@@ -30,6 +30,8 @@ func NewRaw() *Config {
 type Config struct {
 	Version string `yaml:"version"`
 
+	Imports []*ImportConfig `yaml:"imports,omitempty"`
+
 	Images       map[string]*ImageConfig `yaml:"images,omitempty"`
 	Deployments  []*DeploymentConfig     `yaml:"deployments,omitempty"`
 	Dev          *DevConfig              `yaml:"dev,omitempty"`
@@ -235,6 +237,9 @@ type ChartConfig struct {
 	RepoURL  string `yaml:"repo,omitempty"`
 	Username string `yaml:"username,omitempty"`
 	Password string `yaml:"password,omitempty"`
+	CertFile string `yaml:"certFile,omitempty"`
+	KeyFile  string `yaml:"keyFile,omitempty"`
+	CAFile   string `yaml:"caFile,omitempty"`
 }
 
 // KubectlConfig defines the specific kubectl options used during deployment
@@ -242,18 +247,21 @@ type KubectlConfig struct {
 	Manifests        []string `yaml:"manifests,omitempty"`
 	Kustomize        *bool    `yaml:"kustomize,omitempty"`
 	ReplaceImageTags *bool    `yaml:"replaceImageTags,omitempty"`
+	Template         *bool    `yaml:"template,omitempty"`
 	Flags            []string `yaml:"flags,omitempty"`
 	CmdPath          string   `yaml:"cmdPath,omitempty"`
 }
 
 // DevConfig defines the devspace deployment
 type DevConfig struct {
-	Ports       []*PortForwardingConfig `yaml:"ports,omitempty"`
-	Open        []*OpenConfig           `yaml:"open,omitempty"`
-	Sync        []*SyncConfig           `yaml:"sync,omitempty"`
-	Logs        *LogsConfig             `yaml:"logs,omitempty"`
-	AutoReload  *AutoReloadConfig       `yaml:"autoReload,omitempty"`
-	Interactive *InteractiveConfig      `yaml:"interactive,omitempty"`
+	Ports       []*PortForwardingConfig  `yaml:"ports,omitempty"`
+	Hosts       *HostsConfig             `yaml:"hosts,omitempty"`
+	Open        []*OpenConfig            `yaml:"open,omitempty"`
+	Sync        []*SyncConfig            `yaml:"sync,omitempty"`
+	Terminals   []*TerminalSessionConfig `yaml:"terminals,omitempty"`
+	Logs        *LogsConfig              `yaml:"logs,omitempty"`
+	AutoReload  *AutoReloadConfig        `yaml:"autoReload,omitempty"`
+	Interactive *InteractiveConfig       `yaml:"interactive,omitempty"`
 }
 
 // PortForwardingConfig defines the ports for a port forwarding to a DevSpace
@@ -261,14 +269,23 @@ type PortForwardingConfig struct {
 	ImageName     string            `yaml:"imageName,omitempty"`
 	LabelSelector map[string]string `yaml:"labelSelector,omitempty"`
 	Namespace     string            `yaml:"namespace,omitempty"`
+	Service       string            `yaml:"service,omitempty"`
 	PortMappings  []*PortMapping    `yaml:"forward,omitempty"`
+
+	ReverseForward []*PortMapping `yaml:"reverseForward,omitempty"`
+}
+
+// HostsConfig defines if services that are forwarded are reachable with their cluster hostnames on the local machine
+type HostsConfig struct {
+	Enabled   *bool  `yaml:"enabled,omitempty"`
+	HostsFile string `yaml:"hostsFile,omitempty"`
 }
 
 // PortMapping defines the ports for a PortMapping
 type PortMapping struct {
-	LocalPort   *int   `yaml:"port"`
-	RemotePort  *int   `yaml:"remotePort,omitempty"`
-	BindAddress string `yaml:"bindAddress,omitempty"`
+	LocalPort   *int    `yaml:"port"`
+	RemotePort  *string `yaml:"remotePort,omitempty"`
+	BindAddress string  `yaml:"bindAddress,omitempty"`
 }
 
 // OpenConfig defines what to open after services have been started
@@ -300,9 +317,14 @@ type BandwidthLimits struct {
 
 // LogsConfig specifies the logs options for devspace dev
 type LogsConfig struct {
-	Disabled *bool    `yaml:"disabled,omitempty"`
-	ShowLast *int     `yaml:"showLast,omitempty"`
-	Images   []string `yaml:"images,omitempty"`
+	Disabled      *bool             `yaml:"disabled,omitempty"`
+	ShowLast      *int              `yaml:"showLast,omitempty"`
+	Images        []string          `yaml:"images,omitempty"`
+	LabelSelector map[string]string `yaml:"labelSelector,omitempty"`
+	Deployments   []string          `yaml:"deployments,omitempty"`
+	Include       []string          `yaml:"include,omitempty"`
+	Exclude       []string          `yaml:"exclude,omitempty"`
+	FormatJSON    *bool             `yaml:"formatJSON,omitempty"`
 }
 
 // AutoReloadConfig defines the struct for auto reloading devspace with additional paths
@@ -321,9 +343,32 @@ type InteractiveConfig struct {
 
 // InteractiveImageConfig describes the interactive mode options for an image
 type InteractiveImageConfig struct {
-	Name       string   `yaml:"name,omitempty"`
-	Entrypoint []string `yaml:"entrypoint,omitempty"`
-	Cmd        []string `yaml:"cmd,omitempty"`
+	Name       string       `yaml:"name,omitempty"`
+	Entrypoint []string     `yaml:"entrypoint,omitempty"`
+	Cmd        []string     `yaml:"cmd,omitempty"`
+	Debug      *DebugConfig `yaml:"debug,omitempty"`
+}
+
+// DebugConfig defines how the application of an image is started with a remote debugger in interactive mode
+type DebugConfig struct {
+	Language   string   `yaml:"language,omitempty"`
+	Command    []string `yaml:"command,omitempty"`
+	Port       *int     `yaml:"port,omitempty"`
+	RemoteRoot string   `yaml:"remoteRoot,omitempty"`
+	VSCode     *bool    `yaml:"vscode,omitempty"`
+}
+
+// TerminalSessionConfig defines a persistent terminal session in a container that can be entered with
+// devspace enter --session
+type TerminalSessionConfig struct {
+	Name          string            `yaml:"name"`
+	ImageName     string            `yaml:"imageName,omitempty"`
+	LabelSelector map[string]string `yaml:"labelSelector,omitempty"`
+	ContainerName string            `yaml:"containerName,omitempty"`
+	Namespace     string            `yaml:"namespace,omitempty"`
+	Command       []string          `yaml:"command,omitempty"`
+	WorkDir       string            `yaml:"workDir,omitempty"`
+	Env           map[string]string `yaml:"env,omitempty"`
 }
 
 // TerminalConfig describes the terminal options
@@ -337,11 +382,22 @@ type TerminalConfig struct {
 
 // DependencyConfig defines the devspace dependency
 type DependencyConfig struct {
-	Source             *SourceConfig `yaml:"source"`
-	Profile            string        `yaml:"profile,omitempty"`
-	SkipBuild          *bool         `yaml:"skipBuild,omitempty"`
-	IgnoreDependencies *bool         `yaml:"ignoreDependencies,omitempty"`
-	Namespace          string        `yaml:"namespace,omitempty"`
+	Source             *SourceConfig    `yaml:"source"`
+	Profile            string           `yaml:"profile,omitempty"`
+	SkipBuild          *bool            `yaml:"skipBuild,omitempty"`
+	IgnoreDependencies *bool            `yaml:"ignoreDependencies,omitempty"`
+	Namespace          string           `yaml:"namespace,omitempty"`
+	Dev                *bool            `yaml:"dev,omitempty"`
+	DevPorts           *bool            `yaml:"devPorts,omitempty"`
+	DevSync            *bool            `yaml:"devSync,omitempty"`
+	Vars               []*DependencyVar `yaml:"vars,omitempty"`
+	Overrides          []*PatchConfig   `yaml:"overrides,omitempty"`
+}
+
+// DependencyVar defines a variable value that is passed to a dependency
+type DependencyVar struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
 }
 
 // SourceConfig defines the dependency source
@@ -353,6 +409,10 @@ type SourceConfig struct {
 	Revision string `yaml:"revision,omitempty"`
 
 	Path string `yaml:"path,omitempty"`
+
+	URL      string `yaml:"url,omitempty"`
+	OCI      string `yaml:"oci,omitempty"`
+	Checksum string `yaml:"checksum,omitempty"`
 }
 
 // HookConfig defines a hook
@@ -391,6 +451,10 @@ type Variable struct {
 	ValidationMessage string          `yaml:"validationMessage,omitempty"`
 	Default           string          `yaml:"default,omitempty"`
 	Source            *VariableSource `yaml:"source,omitempty"`
+	Command           string          `yaml:"command,omitempty"`
+	File              string          `yaml:"file,omitempty"`
+	Secret            *VariableSecret `yaml:"secret,omitempty"`
+	Sensitive         bool            `yaml:"sensitive,omitempty"`
 }
 
 // VariableSource is type of a variable source
@@ -398,16 +462,39 @@ type VariableSource string
 
 // List of values that source can take
 const (
-	VariableSourceAll   VariableSource = "all"
-	VariableSourceEnv   VariableSource = "env"
-	VariableSourceInput VariableSource = "input"
+	VariableSourceAll     VariableSource = "all"
+	VariableSourceEnv     VariableSource = "env"
+	VariableSourceInput   VariableSource = "input"
+	VariableSourceCommand VariableSource = "command"
+	VariableSourceFile    VariableSource = "file"
+	VariableSourceSecret  VariableSource = "secret"
 )
 
+// VariableSecret defines where the value of a variable with source secret is stored
+type VariableSecret struct {
+	Store string `yaml:"store,omitempty"`
+	Key   string `yaml:"key,omitempty"`
+	Path  string `yaml:"path,omitempty"`
+}
+
+// ImportConfig defines another config file that is merged into the config
+type ImportConfig struct {
+	Path string `yaml:"path,omitempty"`
+
+	Git      string `yaml:"git,omitempty"`
+	Branch   string `yaml:"branch,omitempty"`
+	Tag      string `yaml:"tag,omitempty"`
+	Revision string `yaml:"revision,omitempty"`
+}
+
 // ProfileConfig defines a profile config
 type ProfileConfig struct {
-	Name    string         `yaml:"name"`
-	Patches []*PatchConfig `yaml:"patches,omitempty"`
-	Replace *ReplaceConfig `yaml:"replace,omitempty"`
+	Name    string                      `yaml:"name"`
+	Parent  string                      `yaml:"parent,omitempty"`
+	Parents []string                    `yaml:"parents,omitempty"`
+	Patches []*PatchConfig              `yaml:"patches,omitempty"`
+	Replace *ReplaceConfig              `yaml:"replace,omitempty"`
+	Merge   map[interface{}]interface{} `yaml:"merge,omitempty"`
 }
 
 // PatchConfig describes a config patch and how it should be applied
@@ -6,6 +6,7 @@ import (
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 	"github.com/pkg/errors"
 )
 
@@ -93,7 +94,7 @@ func RemovePort(baseConfig *latest.Config, removeAll bool, labelSelector string,
 				if pm.LocalPort != nil && containsPort(strconv.Itoa(*pm.LocalPort), ports) {
 					continue
 				}
-				if pm.RemotePort != nil && containsPort(strconv.Itoa(*pm.RemotePort), ports) {
+				if pm.RemotePort != nil && containsPort(*pm.RemotePort, ports) {
 					continue
 				}
 
@@ -186,12 +187,8 @@ func parsePortMappings(portMappingsString string) ([]*latest.PortMapping, error)
 		} else {
 			portMappingStruct.LocalPort = &firstPort
 
-			secondPort, err := strconv.Atoi(portMapping[1])
-
-			if err != nil {
-				return nil, err
-			}
-			portMappingStruct.RemotePort = &secondPort
+			// The remote port can also be the name of a container port
+			portMappingStruct.RemotePort = ptr.String(strings.TrimSpace(portMapping[1]))
 		}
 
 		portMappings = append(portMappings, portMappingStruct)
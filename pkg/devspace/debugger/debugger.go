@@ -0,0 +1,262 @@
+package debugger
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// Supported languages
+const (
+	LanguageGo     = "go"
+	LanguageNode   = "node"
+	LanguagePython = "python"
+	LanguageJava   = "java"
+)
+
+// LaunchConfigPath is the path of the VS Code launch configuration relative to the project root
+var LaunchConfigPath = filepath.Join(".vscode", "launch.json")
+
+// DefaultPorts holds the port the debugger of a language listens on by default
+var DefaultPorts = map[string]int{
+	LanguageGo:     2345,
+	LanguageNode:   9229,
+	LanguagePython: 5678,
+	LanguageJava:   5005,
+}
+
+// Configure overrides the entrypoint of the interactive image with the debugger launch command, forwards the debug
+// port and prints or writes the VS Code attach configuration
+func Configure(config *latest.Config, imageConf *latest.InteractiveImageConfig, log log.Logger) error {
+	debugConfig := imageConf.Debug
+
+	// Start the command of the image with the debugger if no command is configured. The interactive entrypoint and
+	// cmd overrides take precedence over the ones of the image
+	command := debugConfig.Command
+	if len(command) == 0 {
+		entrypoint, cmd := imageConf.Entrypoint, imageConf.Cmd
+		if len(entrypoint) == 0 && config.Images != nil && config.Images[imageConf.Name] != nil {
+			entrypoint = config.Images[imageConf.Name].Entrypoint
+			if len(cmd) == 0 {
+				cmd = config.Images[imageConf.Name].Cmd
+			}
+		}
+
+		command = append(append([]string{}, entrypoint...), cmd...)
+	}
+
+	entrypoint, err := GetCommand(debugConfig, command)
+	if err != nil {
+		return errors.Wrapf(err, "debug image %s", imageConf.Name)
+	}
+
+	imageConf.Entrypoint = entrypoint
+	imageConf.Cmd = nil
+
+	// Forward the debug port if it is not forwarded yet
+	port := GetPort(debugConfig)
+	if isPortForwarded(config, port) == false {
+		config.Dev.Ports = append(config.Dev.Ports, &latest.PortForwardingConfig{
+			ImageName: imageConf.Name,
+			PortMappings: []*latest.PortMapping{
+				{
+					LocalPort: &port,
+				},
+			},
+		})
+	}
+
+	launchConfig := GetLaunchConfig(imageConf.Name, debugConfig, getRemoteRoot(config, imageConf))
+	if debugConfig.VSCode != nil && *debugConfig.VSCode == true {
+		err = WriteLaunchConfig(LaunchConfigPath, launchConfig)
+		if err != nil {
+			return errors.Wrap(err, "write VS Code launch configuration")
+		}
+
+		log.Infof("Debugger of image '%s' is available on localhost:%d (VS Code: '%s' in %s)", imageConf.Name, port, launchConfig["name"], LaunchConfigPath)
+		return nil
+	}
+
+	out, err := json.MarshalIndent(launchConfig, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Debugger of image '%s' is available on localhost:%d. VS Code launch configuration:\n%s", imageConf.Name, port, string(out))
+	return nil
+}
+
+func isPortForwarded(config *latest.Config, port int) bool {
+	for _, portForwarding := range config.Dev.Ports {
+		for _, portMapping := range portForwarding.PortMappings {
+			if portMapping.LocalPort != nil && *portMapping.LocalPort == port {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// getRemoteRoot returns the folder of the source code in the container. By default, the container path of the
+// first sync path of the image is used
+func getRemoteRoot(config *latest.Config, imageConf *latest.InteractiveImageConfig) string {
+	if imageConf.Debug.RemoteRoot != "" {
+		return imageConf.Debug.RemoteRoot
+	}
+
+	for _, syncConfig := range config.Dev.Sync {
+		if syncConfig.ImageName == imageConf.Name && syncConfig.ContainerPath != "" {
+			return syncConfig.ContainerPath
+		}
+	}
+
+	return ""
+}
+
+// GetPort returns the port the debugger listens on
+func GetPort(debugConfig *latest.DebugConfig) int {
+	if debugConfig.Port != nil {
+		return *debugConfig.Port
+	}
+
+	return DefaultPorts[debugConfig.Language]
+}
+
+// GetCommand returns the command that starts the application with the debugger. The command is the command that
+// starts the application without debugger, e.g. [node, index.js]
+func GetCommand(debugConfig *latest.DebugConfig, command []string) ([]string, error) {
+	if len(command) == 0 {
+		return nil, errors.New("Please specify the command that starts your application in debug.command")
+	}
+
+	port := strconv.Itoa(GetPort(debugConfig))
+	switch debugConfig.Language {
+	case LanguageGo:
+		return append([]string{"dlv", "exec", command[0], "--headless", "--listen=0.0.0.0:" + port, "--api-version=2", "--accept-multiclient", "--continue", "--"}, command[1:]...), nil
+	case LanguageNode:
+		interpreter, args := splitInterpreter(command, "node", "nodejs")
+		return append([]string{interpreter, "--inspect=0.0.0.0:" + port}, args...), nil
+	case LanguagePython:
+		interpreter, args := splitInterpreter(command, "python", "python2", "python3")
+		return append([]string{interpreter, "-m", "debugpy", "--listen", "0.0.0.0:" + port}, args...), nil
+	case LanguageJava:
+		interpreter, args := splitInterpreter(command, "java")
+		return append([]string{interpreter, "-agentlib:jdwp=transport=dt_socket,server=y,suspend=n,address=*:" + port}, args...), nil
+	}
+
+	return nil, errors.Errorf("Unsupported debug language %s. Supported languages are %s", debugConfig.Language, strings.Join(Languages(), ", "))
+}
+
+// Languages returns the supported languages
+func Languages() []string {
+	return []string{LanguageGo, LanguageNode, LanguagePython, LanguageJava}
+}
+
+// splitInterpreter splits the interpreter from the arguments of the command. If the command does not start with
+// one of the interpreters, the first interpreter is used and the whole command is treated as arguments
+func splitInterpreter(command []string, interpreters ...string) (string, []string) {
+	for _, interpreter := range interpreters {
+		if command[0] == interpreter || strings.HasSuffix(command[0], "/"+interpreter) {
+			return command[0], command[1:]
+		}
+	}
+
+	return interpreters[0], command
+}
+
+// GetLaunchConfig returns the VS Code configuration that attaches to the debugger of the image
+func GetLaunchConfig(imageName string, debugConfig *latest.DebugConfig, remoteRoot string) map[string]interface{} {
+	port := GetPort(debugConfig)
+	launchConfig := map[string]interface{}{
+		"name":    "DevSpace: Attach to " + imageName,
+		"request": "attach",
+	}
+
+	switch debugConfig.Language {
+	case LanguageGo:
+		launchConfig["type"] = "go"
+		launchConfig["mode"] = "remote"
+		launchConfig["host"] = "127.0.0.1"
+		launchConfig["port"] = port
+		if remoteRoot != "" {
+			launchConfig["remotePath"] = remoteRoot
+		}
+	case LanguageNode:
+		launchConfig["type"] = "node"
+		launchConfig["address"] = "127.0.0.1"
+		launchConfig["port"] = port
+		if remoteRoot != "" {
+			launchConfig["localRoot"] = "${workspaceFolder}"
+			launchConfig["remoteRoot"] = remoteRoot
+		}
+	case LanguagePython:
+		launchConfig["type"] = "python"
+		launchConfig["connect"] = map[string]interface{}{
+			"host": "127.0.0.1",
+			"port": port,
+		}
+		if remoteRoot != "" {
+			launchConfig["pathMappings"] = []interface{}{
+				map[string]interface{}{
+					"localRoot":  "${workspaceFolder}",
+					"remoteRoot": remoteRoot,
+				},
+			}
+		}
+	case LanguageJava:
+		launchConfig["type"] = "java"
+		launchConfig["hostName"] = "127.0.0.1"
+		launchConfig["port"] = port
+	}
+
+	return launchConfig
+}
+
+// WriteLaunchConfig adds the configuration to the VS Code launch configuration at the given path. An existing
+// configuration with the same name is replaced
+func WriteLaunchConfig(path string, launchConfig map[string]interface{}) error {
+	launch := map[string]interface{}{
+		"version": "0.2.0",
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err == nil {
+		err = json.Unmarshal(content, &launch)
+		if err != nil {
+			return errors.Errorf("Couldn't parse %s: %v", path, err)
+		}
+	} else if os.IsNotExist(err) == false {
+		return err
+	}
+
+	configurations, _ := launch["configurations"].([]interface{})
+	newConfigurations := []interface{}{}
+	for _, configuration := range configurations {
+		if configurationMap, ok := configuration.(map[string]interface{}); ok && configurationMap["name"] == launchConfig["name"] {
+			continue
+		}
+
+		newConfigurations = append(newConfigurations, configuration)
+	}
+	launch["configurations"] = append(newConfigurations, launchConfig)
+
+	content, err = json.MarshalIndent(launch, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, append(content, '\n'), 0644)
+}
@@ -0,0 +1,187 @@
+package debugger
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	"gotest.tools/assert"
+)
+
+type getCommandTestCase struct {
+	name string
+
+	debugConfig *latest.DebugConfig
+	command     []string
+
+	expectedCommand []string
+	expectedErr     string
+}
+
+func TestGetCommand(t *testing.T) {
+	testCases := []getCommandTestCase{
+		getCommandTestCase{
+			name:            "Go",
+			debugConfig:     &latest.DebugConfig{Language: LanguageGo},
+			command:         []string{"/app/main", "--verbose"},
+			expectedCommand: []string{"dlv", "exec", "/app/main", "--headless", "--listen=0.0.0.0:2345", "--api-version=2", "--accept-multiclient", "--continue", "--", "--verbose"},
+		},
+		getCommandTestCase{
+			name:            "Node with interpreter",
+			debugConfig:     &latest.DebugConfig{Language: LanguageNode, Port: ptr.Int(9230)},
+			command:         []string{"node", "index.js"},
+			expectedCommand: []string{"node", "--inspect=0.0.0.0:9230", "index.js"},
+		},
+		getCommandTestCase{
+			name:            "Node without interpreter",
+			debugConfig:     &latest.DebugConfig{Language: LanguageNode},
+			command:         []string{"index.js"},
+			expectedCommand: []string{"node", "--inspect=0.0.0.0:9229", "index.js"},
+		},
+		getCommandTestCase{
+			name:            "Python",
+			debugConfig:     &latest.DebugConfig{Language: LanguagePython},
+			command:         []string{"/usr/bin/python3", "app.py"},
+			expectedCommand: []string{"/usr/bin/python3", "-m", "debugpy", "--listen", "0.0.0.0:5678", "app.py"},
+		},
+		getCommandTestCase{
+			name:            "Java",
+			debugConfig:     &latest.DebugConfig{Language: LanguageJava},
+			command:         []string{"java", "-jar", "app.jar"},
+			expectedCommand: []string{"java", "-agentlib:jdwp=transport=dt_socket,server=y,suspend=n,address=*:5005", "-jar", "app.jar"},
+		},
+		getCommandTestCase{
+			name:        "Unsupported language",
+			debugConfig: &latest.DebugConfig{Language: "ruby"},
+			command:     []string{"ruby", "app.rb"},
+			expectedErr: "Unsupported debug language ruby. Supported languages are go, node, python, java",
+		},
+		getCommandTestCase{
+			name:        "No command",
+			debugConfig: &latest.DebugConfig{Language: LanguageGo},
+			expectedErr: "Please specify the command that starts your application in debug.command",
+		},
+	}
+
+	for _, testCase := range testCases {
+		command, err := GetCommand(testCase.debugConfig, testCase.command)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+		}
+
+		assert.DeepEqual(t, command, testCase.expectedCommand)
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	wdBackup, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Error getting current working directory: %v", err)
+	}
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatalf("Error changing working directory: %v", err)
+	}
+	defer os.Chdir(wdBackup)
+
+	imageConf := &latest.InteractiveImageConfig{
+		Name: "backend",
+		Debug: &latest.DebugConfig{
+			Language: LanguageNode,
+			VSCode:   ptr.Bool(true),
+		},
+	}
+	config := &latest.Config{
+		Images: map[string]*latest.ImageConfig{
+			"backend": &latest.ImageConfig{
+				Entrypoint: []string{"node"},
+				Cmd:        []string{"index.js"},
+			},
+		},
+		Dev: &latest.DevConfig{
+			Sync: []*latest.SyncConfig{
+				&latest.SyncConfig{
+					ImageName:     "backend",
+					ContainerPath: "/app",
+				},
+			},
+			Interactive: &latest.InteractiveConfig{
+				Images: []*latest.InteractiveImageConfig{imageConf},
+			},
+		},
+	}
+
+	err = Configure(config, imageConf, log.Discard)
+	assert.NilError(t, err, "Error configuring debugger")
+	assert.DeepEqual(t, imageConf.Entrypoint, []string{"node", "--inspect=0.0.0.0:9229", "index.js"})
+	assert.Equal(t, len(config.Dev.Ports), 1)
+	assert.Equal(t, *config.Dev.Ports[0].PortMappings[0].LocalPort, 9229)
+
+	// Configuring twice (e.g. after a reload) does not duplicate the port forwarding or the launch configuration
+	imageConf.Entrypoint = nil
+	err = Configure(config, imageConf, log.Discard)
+	assert.NilError(t, err, "Error configuring debugger twice")
+	assert.Equal(t, len(config.Dev.Ports), 1)
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, LaunchConfigPath))
+	assert.NilError(t, err, "Error reading launch configuration")
+
+	launch := map[string]interface{}{}
+	err = json.Unmarshal(content, &launch)
+	assert.NilError(t, err, "Error parsing launch configuration")
+
+	configurations := launch["configurations"].([]interface{})
+	assert.Equal(t, len(configurations), 1)
+	assert.Equal(t, configurations[0].(map[string]interface{})["remoteRoot"], "/app")
+}
+
+func TestConfigureWithInteractiveOverrides(t *testing.T) {
+	imageConf := &latest.InteractiveImageConfig{
+		Name:       "backend",
+		Entrypoint: []string{"node"},
+		Cmd:        []string{"debug.js"},
+		Debug: &latest.DebugConfig{
+			Language: LanguageNode,
+		},
+	}
+	config := &latest.Config{
+		Images: map[string]*latest.ImageConfig{
+			"backend": &latest.ImageConfig{
+				Entrypoint: []string{"node"},
+				Cmd:        []string{"index.js"},
+			},
+		},
+		Dev: &latest.DevConfig{
+			Interactive: &latest.InteractiveConfig{
+				Images: []*latest.InteractiveImageConfig{imageConf},
+			},
+		},
+	}
+
+	// The interactive overrides are started with the debugger instead of the entrypoint and cmd of the image
+	err := Configure(config, imageConf, log.Discard)
+	assert.NilError(t, err, "Error configuring debugger")
+	assert.DeepEqual(t, imageConf.Entrypoint, []string{"node", "--inspect=0.0.0.0:9229", "debug.js"})
+	assert.Assert(t, imageConf.Cmd == nil, "Cmd override was not removed")
+
+	// Only overriding cmd keeps the entrypoint of the image
+	imageConf.Entrypoint = nil
+	imageConf.Cmd = []string{"other.js"}
+	err = Configure(config, imageConf, log.Discard)
+	assert.NilError(t, err, "Error configuring debugger")
+	assert.DeepEqual(t, imageConf.Entrypoint, []string{"node", "--inspect=0.0.0.0:9229", "other.js"})
+}
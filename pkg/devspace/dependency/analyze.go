@@ -0,0 +1,308 @@
+package dependency
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/custom"
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/helper"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/hash"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+
+	"github.com/pkg/errors"
+)
+
+// Analysis is the resolved dependency graph of a project
+type Analysis struct {
+	Root         string                `json:"root"`
+	Dependencies []*DependencyAnalysis `json:"dependencies"`
+
+	// Diamonds holds the ids of dependencies that are required by more than one project
+	Diamonds []string `json:"diamonds,omitempty"`
+	// Cycles holds the cyclic dependency paths that were found
+	Cycles [][]string `json:"cycles,omitempty"`
+}
+
+// DependencyAnalysis describes a single resolved dependency
+type DependencyAnalysis struct {
+	ID         string   `json:"id"`
+	Source     string   `json:"source"`
+	Revision   string   `json:"revision,omitempty"`
+	Profile    string   `json:"profile,omitempty"`
+	Namespace  string   `json:"namespace,omitempty"`
+	DependsOn  []string `json:"dependsOn,omitempty"`
+	RequiredBy []string `json:"requiredBy,omitempty"`
+
+	// Rebuild and Redeploy tell if the dependency would be built and deployed on the next deployment
+	Rebuild  bool `json:"rebuild"`
+	Redeploy bool `json:"redeploy"`
+}
+
+// Analyze resolves all dependencies of the config and returns the dependency graph. Cyclic dependencies do not
+// result in an error, instead they are returned in the analysis. Neither the lock file nor the generated config are saved
+func Analyze(config *latest.Config, cache *generated.Config, frozenLock bool, configOptions *configutil.ConfigOptions, log log.Logger) (*Analysis, error) {
+	resolver, err := NewResolver(config, cache, true, frozenLock, log)
+	if err != nil {
+		return nil, errors.Wrap(err, "new resolver")
+	}
+
+	resolver.ReadOnly = true
+
+	analysis := &Analysis{
+		Root:         resolver.DependencyGraph.Root.ID,
+		Dependencies: []*DependencyAnalysis{},
+	}
+	if config == nil || len(config.Dependencies) == 0 {
+		return analysis, nil
+	}
+
+	dependencies, err := resolver.Resolve(config.Dependencies, configOptions, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dependency := range dependencies {
+		dependencyAnalysis, err := dependency.analyze()
+		if err != nil {
+			return nil, errors.Wrapf(err, "analyze dependency %s", dependency.ID)
+		}
+
+		if len(dependency.requiredBy) > 1 {
+			analysis.Diamonds = append(analysis.Diamonds, dependency.ID)
+		}
+
+		analysis.Dependencies = append(analysis.Dependencies, dependencyAnalysis)
+	}
+
+	for _, cycle := range resolver.cycles {
+		ids := []string{cycle.path[len(cycle.path)-1].ID}
+		for _, node := range cycle.path {
+			ids = append(ids, node.ID)
+		}
+
+		analysis.Cycles = append(analysis.Cycles, ids)
+	}
+
+	return analysis, nil
+}
+
+func (d *Dependency) analyze() (*DependencyAnalysis, error) {
+	analysis := &DependencyAnalysis{
+		ID:         d.ID,
+		Source:     getSource(d.DependencyConfig),
+		Revision:   d.revision,
+		Profile:    d.DependencyConfig.Profile,
+		Namespace:  d.DependencyConfig.Namespace,
+		DependsOn:  d.dependsOn,
+		RequiredBy: d.requiredBy,
+	}
+
+	// Check if the dependency changed since the last deployment
+	directoryHash, err := hash.DirectoryExcludes(d.LocalPath, []string{".git", ".devspace"}, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "hash directory")
+	}
+
+	analysis.Redeploy = directoryHash != d.getCachedHash()
+	if analysis.Redeploy {
+		analysis.Rebuild, err = d.shouldRebuild()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return analysis, nil
+}
+
+// shouldRebuild checks if any image of the dependency would be rebuilt according to its generated config
+func (d *Dependency) shouldRebuild() (bool, error) {
+	if d.DependencyConfig.SkipBuild != nil && *d.DependencyConfig.SkipBuild == true {
+		return false, nil
+	}
+
+	rebuild := false
+	err := d.inWorkingDirectory(func() error {
+		for imageConfigName, imageConf := range d.Config.Images {
+			if imageConf.Build != nil && imageConf.Build.Disabled != nil && *imageConf.Build.Disabled == true {
+				continue
+			}
+
+			var (
+				shouldRebuild bool
+				err           error
+			)
+			if imageConf.Build != nil && imageConf.Build.Custom != nil {
+				shouldRebuild, err = custom.NewBuilder(imageConfigName, imageConf, "").ShouldRebuild(d.GeneratedConfig.GetActive(), false)
+			} else {
+				shouldRebuild, err = helper.NewBuildHelper(d.Config, nil, "", imageConfigName, imageConf, "", false).ShouldRebuild(d.GeneratedConfig.GetActive(), false)
+			}
+			if err != nil {
+				return errors.Wrapf(err, "check image %s", imageConfigName)
+			}
+
+			if shouldRebuild {
+				rebuild = true
+				return nil
+			}
+		}
+
+		return nil
+	})
+
+	return rebuild, err
+}
+
+func getSource(dependency *latest.DependencyConfig) string {
+	if dependency.Source == nil {
+		return ""
+	}
+
+	if dependency.Source.Git != "" {
+		source := authRegEx.ReplaceAllString(strings.TrimSpace(dependency.Source.Git), "$1$2")
+		if dependency.Source.Tag != "" {
+			source += " (tag " + dependency.Source.Tag + ")"
+		} else if dependency.Source.Branch != "" {
+			source += " (branch " + dependency.Source.Branch + ")"
+		} else if dependency.Source.Revision != "" {
+			source += " (revision " + dependency.Source.Revision + ")"
+		}
+
+		return source
+	}
+
+	if dependency.Source.URL != "" {
+		return authRegEx.ReplaceAllString(strings.TrimSpace(dependency.Source.URL), "$1$2")
+	} else if dependency.Source.OCI != "" {
+		return strings.TrimSpace(dependency.Source.OCI)
+	}
+
+	return dependency.Source.Path
+}
+
+// Tree returns the dependency graph as a human readable tree. Dependencies that are required by more than one
+// project are only expanded once
+func (a *Analysis) Tree() string {
+	var (
+		out          = &strings.Builder{}
+		dependencies = map[string]*DependencyAnalysis{}
+		printed      = map[string]bool{}
+		rootChilds   = []string{}
+	)
+
+	for _, dependency := range a.Dependencies {
+		dependencies[dependency.ID] = dependency
+		for _, parent := range dependency.RequiredBy {
+			if parent == a.Root {
+				rootChilds = append(rootChilds, dependency.ID)
+			}
+		}
+	}
+	sort.Strings(rootChilds)
+
+	var printNode func(id, prefix string, last bool)
+	printNode = func(id, prefix string, last bool) {
+		branch, childPrefix := "├── ", "│   "
+		if last {
+			branch, childPrefix = "└── ", "    "
+		}
+
+		dependency, ok := dependencies[id]
+		if !ok {
+			return
+		}
+		if printed[id] {
+			fmt.Fprintf(out, "%s%s%s (see above)\n", prefix, branch, id)
+			return
+		}
+		printed[id] = true
+
+		fmt.Fprintf(out, "%s%s%s %s\n", prefix, branch, id, dependency.describe())
+
+		childs := append([]string{}, dependency.DependsOn...)
+		sort.Strings(childs)
+		for idx, child := range childs {
+			printNode(child, prefix+childPrefix, idx == len(childs)-1)
+		}
+	}
+
+	out.WriteString(a.Root + "\n")
+	for idx, id := range rootChilds {
+		printNode(id, "", idx == len(rootChilds)-1)
+	}
+
+	return out.String()
+}
+
+func (d *DependencyAnalysis) describe() string {
+	details := []string{}
+	if d.Source != "" && d.Source != d.ID {
+		details = append(details, "source: "+d.Source)
+	}
+	if d.Revision != "" {
+		revision := d.Revision
+		if len(revision) > 7 {
+			revision = revision[:7]
+		}
+
+		details = append(details, "revision: "+revision)
+	}
+	if d.Profile != "" {
+		details = append(details, "profile: "+d.Profile)
+	}
+	if d.Namespace != "" {
+		details = append(details, "namespace: "+d.Namespace)
+	}
+
+	if d.Rebuild {
+		details = append(details, "rebuild")
+	}
+	if d.Redeploy {
+		details = append(details, "redeploy")
+	} else {
+		details = append(details, "up to date")
+	}
+
+	return "[" + strings.Join(details, ", ") + "]"
+}
+
+// DOT returns the dependency graph in the graphviz dot format
+func (a *Analysis) DOT() string {
+	out := &strings.Builder{}
+	out.WriteString("digraph dependencies {\n")
+	fmt.Fprintf(out, "  %s [shape=box];\n", strconv.Quote(a.Root))
+
+	diamonds := map[string]bool{}
+	for _, id := range a.Diamonds {
+		diamonds[id] = true
+	}
+
+	for _, dependency := range a.Dependencies {
+		attributes := []string{"label=" + strconv.Quote(dependency.ID+"\n"+dependency.describe())}
+		if diamonds[dependency.ID] {
+			attributes = append(attributes, "color=orange")
+		}
+
+		fmt.Fprintf(out, "  %s [%s];\n", strconv.Quote(dependency.ID), strings.Join(attributes, ", "))
+	}
+
+	for _, dependency := range a.Dependencies {
+		for _, parent := range dependency.RequiredBy {
+			fmt.Fprintf(out, "  %s -> %s;\n", strconv.Quote(parent), strconv.Quote(dependency.ID))
+		}
+	}
+
+	for _, cycle := range a.Cycles {
+		if len(cycle) > 1 {
+			// The first edge of the cycle is the one that was not added to the graph
+			fmt.Fprintf(out, "  %s -> %s [color=red, style=dashed];\n", strconv.Quote(cycle[0]), strconv.Quote(cycle[1]))
+		}
+	}
+
+	out.WriteString("}\n")
+	return out.String()
+}
@@ -0,0 +1,95 @@
+package dependency
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+
+	"gotest.tools/assert"
+)
+
+func TestAnalyze(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testAnalyze")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	dir, err = filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wdBackup, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Error getting current working directory: %v", err)
+	}
+	defer func() {
+		os.Chdir(wdBackup)
+		os.RemoveAll(dir)
+	}()
+
+	// root -> a, b; a -> c; b -> c; c -> a (cyclic)
+	files := map[string]string{
+		"project/devspace.yaml": "version: " + latest.Version,
+		"a/devspace.yaml":       "version: " + latest.Version + "\ndependencies:\n- source:\n    path: ../c\n",
+		"b/devspace.yaml":       "version: " + latest.Version + "\ndependencies:\n- source:\n    path: ../c\n",
+		"c/devspace.yaml":       "version: " + latest.Version + "\ndependencies:\n- source:\n    path: ../a\n",
+	}
+	for path, content := range files {
+		err = fsutil.WriteToFile([]byte(content), filepath.Join(dir, path))
+		assert.NilError(t, err)
+	}
+
+	err = os.Chdir(filepath.Join(dir, "project"))
+	assert.NilError(t, err)
+
+	config := &latest.Config{
+		Dependencies: []*latest.DependencyConfig{
+			&latest.DependencyConfig{Source: &latest.SourceConfig{Path: "../a"}, Profile: ""},
+			&latest.DependencyConfig{Source: &latest.SourceConfig{Path: "../b"}, Namespace: "b"},
+		},
+	}
+
+	analysis, err := Analyze(config, &generated.Config{Profiles: map[string]*generated.CacheConfig{}}, false, &configutil.ConfigOptions{}, log.Discard)
+	assert.NilError(t, err)
+
+	// Analyzing must not change any files of the project
+	_, err = os.Stat(filepath.Join(dir, "project", filepath.FromSlash(generated.ConfigPath)))
+	assert.Equal(t, os.IsNotExist(err), true, "Generated config was saved")
+
+	var (
+		root = filepath.Join(dir, "project")
+		a    = filepath.Join(dir, "a")
+		b    = filepath.Join(dir, "b")
+		c    = filepath.Join(dir, "c")
+	)
+
+	assert.Equal(t, analysis.Root, root)
+	assert.Equal(t, len(analysis.Dependencies), 3)
+	assert.DeepEqual(t, analysis.Diamonds, []string{c})
+	assert.DeepEqual(t, analysis.Cycles, [][]string{{c, a, c}})
+
+	for _, dependency := range analysis.Dependencies {
+		assert.Equal(t, dependency.Redeploy, true, "Dependency %s should be redeployed", dependency.ID)
+		if dependency.ID == b {
+			assert.Equal(t, dependency.Namespace, "b")
+			assert.DeepEqual(t, dependency.DependsOn, []string{c})
+		}
+	}
+
+	tree := analysis.Tree()
+	assert.Equal(t, strings.Count(tree, c+" ["), 1, "Diamond dependency should only be expanded once:\n%s", tree)
+	assert.Equal(t, strings.Contains(tree, c+" (see above)"), true, "Diamond dependency should be referenced:\n%s", tree)
+
+	dot := analysis.DOT()
+	assert.Equal(t, strings.HasPrefix(dot, "digraph dependencies {"), true)
+	assert.Equal(t, strings.Contains(dot, `"`+b+`" -> "`+c+`";`), true, "Missing edge in:\n%s", dot)
+	assert.Equal(t, strings.Contains(dot, `"`+c+`" -> "`+a+`" [color=red, style=dashed];`), true, "Missing cyclic edge in:\n%s", dot)
+}
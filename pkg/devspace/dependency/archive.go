@@ -0,0 +1,291 @@
+package dependency
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/helm"
+	"github.com/devspace-cloud/devspace/pkg/util/hash"
+
+	"github.com/pkg/errors"
+)
+
+// ArchiveFolder is the folder in the dependency folder where downloaded archives are cached by their checksum
+const ArchiveFolder = "archives"
+
+const checksumPrefix = "sha256:"
+
+// archiveClient downloads the archives of url dependencies. The timeout makes sure that a stalled server does not
+// block the dependency resolution forever
+var archiveClient = &http.Client{
+	Timeout: 10 * time.Minute,
+}
+
+// resolveArchive downloads the archive of an url or oci dependency, verifies its checksum and extracts it into the
+// dependency folder. It returns the path to the extracted project and the checksum of the archive
+func (r *Resolver) resolveArchive(ID string, source *latest.SourceConfig, update bool) (string, string, error) {
+	var (
+		localPath      = filepath.Join(DependencyFolderPath, hash.String(ID))
+		checksumFile   = localPath + ".checksum"
+		lockedChecksum = r.lock.GetRevision(ID)
+		expected       = normalizeChecksum(source.Checksum)
+	)
+
+	if lockedChecksum == "" && r.FrozenLock {
+		return "", "", errors.Errorf("Dependency %s is not locked in %s. Please run `devspace update dependencies` and commit %s", ID, LockFile, LockFile)
+	}
+
+	os.MkdirAll(DependencyFolderPath, 0755)
+
+	// The checksum in the config takes precedence over the locked one
+	if expected == "" && update == false {
+		expected = lockedChecksum
+	}
+
+	// Check if the dependency is already extracted
+	if update == false {
+		current, err := ioutil.ReadFile(checksumFile)
+		if err == nil && (expected == "" || expected == string(current)) {
+			if _, err := os.Stat(localPath); err == nil {
+				r.resolvedLock.Dependencies[ID] = &LockedDependency{Revision: string(current)}
+				return localPath, string(current), nil
+			}
+		}
+	}
+
+	archivePath, err := r.fetchArchive(ID, source, expected)
+	if err != nil {
+		return "", "", err
+	}
+
+	checksum, err := fileChecksum(archivePath)
+	if err != nil {
+		return "", "", errors.Wrap(err, "calculate checksum")
+	}
+	if expected != "" && checksum != expected {
+		return "", "", errors.Errorf("Checksum mismatch for dependency %s: expected %s, got %s", ID, expected, checksum)
+	}
+
+	err = extractProject(archivePath, localPath)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "extract %s", archivePath)
+	}
+
+	err = ioutil.WriteFile(checksumFile, []byte(checksum), 0644)
+	if err != nil {
+		return "", "", err
+	}
+
+	r.resolvedLock.Dependencies[ID] = &LockedDependency{Revision: checksum}
+	return localPath, checksum, nil
+}
+
+// fetchArchive returns the path of the archive in the archive cache and downloads it if necessary
+func (r *Resolver) fetchArchive(ID string, source *latest.SourceConfig, expected string) (string, error) {
+	cacheDir := filepath.Join(DependencyFolderPath, ArchiveFolder)
+
+	if expected != "" {
+		cachedPath := getCachedArchivePath(cacheDir, expected)
+		if _, err := os.Stat(cachedPath); err == nil {
+			return cachedPath, nil
+		}
+	}
+
+	var (
+		archivePath string
+		err         error
+	)
+	if source.URL != "" {
+		archivePath, err = downloadArchive(strings.TrimSpace(source.URL), cacheDir)
+	} else {
+		archivePath, _, err = helm.PullOCIArchive(strings.TrimSpace(source.OCI), cacheDir)
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "download dependency %s", ID)
+	}
+
+	r.log.Donef("Downloaded %s", ID)
+	return archivePath, nil
+}
+
+// downloadArchive downloads the archive from the given url into the cache dir and returns its path
+func downloadArchive(url, cacheDir string) (string, error) {
+	resp, err := archiveClient.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("GET %s: %s", authRegEx.ReplaceAllString(url, "$1$2"), resp.Status)
+	}
+
+	err = os.MkdirAll(cacheDir, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	tempFile, err := ioutil.TempFile(cacheDir, "download")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tempFile.Name())
+
+	hasher := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tempFile, hasher), resp.Body)
+	tempFile.Close()
+	if err != nil {
+		return "", err
+	}
+
+	archivePath := getCachedArchivePath(cacheDir, checksumPrefix+hex.EncodeToString(hasher.Sum(nil)))
+	err = os.MkdirAll(filepath.Dir(archivePath), 0755)
+	if err != nil {
+		return "", err
+	}
+
+	return archivePath, os.Rename(tempFile.Name(), archivePath)
+}
+
+// getCachedArchivePath uses the same layout as the oci layer cache, so both can share a folder
+func getCachedArchivePath(cacheDir, checksum string) string {
+	return filepath.Join(cacheDir, "sha256", strings.TrimPrefix(checksum, checksumPrefix)+".tgz")
+}
+
+func normalizeChecksum(checksum string) string {
+	checksum = strings.ToLower(strings.TrimSpace(checksum))
+	if checksum != "" && strings.HasPrefix(checksum, checksumPrefix) == false {
+		checksum = checksumPrefix + checksum
+	}
+
+	return checksum
+}
+
+func fileChecksum(filename string) (string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	_, err = io.Copy(hasher, file)
+	if err != nil {
+		return "", err
+	}
+
+	return checksumPrefix + hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// extractProject extracts the archive into target. If the archive only contains a single folder (like packaged helm charts),
+// the contents of this folder are used. The .devspace folder of a previous extraction is kept
+func extractProject(archivePath, target string) error {
+	tempDir, err := ioutil.TempDir(filepath.Dir(target), "extract")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	err = untar(archivePath, tempDir)
+	if err != nil {
+		return err
+	}
+
+	root := tempDir
+	files, err := ioutil.ReadDir(tempDir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 1 && files[0].IsDir() {
+		root = filepath.Join(tempDir, files[0].Name())
+	}
+
+	// Keep the generated config of the dependency
+	oldDevSpaceFolder := filepath.Join(target, ".devspace")
+	if _, err := os.Stat(oldDevSpaceFolder); err == nil {
+		newDevSpaceFolder := filepath.Join(root, ".devspace")
+		if _, err := os.Stat(newDevSpaceFolder); os.IsNotExist(err) {
+			err = os.Rename(oldDevSpaceFolder, newDevSpaceFolder)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = os.RemoveAll(target)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(root, target)
+}
+
+func untar(archivePath, target string) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gzr, err := gzip.NewReader(file)
+	if err != nil {
+		return errors.Wrap(err, "expected a gzip compressed tar archive")
+	}
+	defer gzr.Close()
+
+	tarReader := tar.NewReader(gzr)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if name == "." {
+			continue
+		}
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return errors.Errorf("archive contains invalid path %s", header.Name)
+		}
+
+		targetPath := filepath.Join(target, filepath.FromSlash(name))
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(targetPath, 0755)
+			if err != nil {
+				return err
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			err = os.MkdirAll(filepath.Dir(targetPath), 0755)
+			if err != nil {
+				return err
+			}
+
+			outFile, err := os.OpenFile(targetPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(header.Mode).Perm())
+			if err != nil {
+				return err
+			}
+
+			_, err = io.Copy(outFile, tarReader)
+			outFile.Close()
+			if err != nil {
+				return err
+			}
+		default:
+			// Links and special files are not needed to deploy a project
+		}
+	}
+}
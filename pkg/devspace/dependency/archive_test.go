@@ -0,0 +1,173 @@
+package dependency
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/hash"
+
+	"gotest.tools/assert"
+)
+
+func createTestArchive(t *testing.T, files map[string]string) []byte {
+	buffer := &bytes.Buffer{}
+	gzw := gzip.NewWriter(buffer)
+	tw := tar.NewWriter(gzw)
+
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		assert.NilError(t, err)
+		_, err = tw.Write([]byte(content))
+		assert.NilError(t, err)
+	}
+
+	assert.NilError(t, tw.Close())
+	assert.NilError(t, gzw.Close())
+	return buffer.Bytes()
+}
+
+func TestResolveArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testArchive")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	dir, err = filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wdBackup, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Error getting current working directory: %v", err)
+	}
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatalf("Error changing working directory: %v", err)
+	}
+
+	dependencyFolderBackup := DependencyFolderPath
+	DependencyFolderPath = filepath.Join(dir, "dependencyFolder")
+
+	defer func() {
+		DependencyFolderPath = dependencyFolderBackup
+		os.Chdir(wdBackup)
+		os.RemoveAll(dir)
+	}()
+
+	// Packaged projects usually contain a single top level folder
+	archive := createTestArchive(t, map[string]string{
+		"bundle/devspace.yaml": "version: " + latest.Version,
+		"bundle/chart/a.yaml":  "a",
+	})
+	checksum := sha256.Sum256(archive)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bundle.tar.gz" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		requests++
+		w.Write(archive)
+	}))
+	defer server.Close()
+
+	url := server.URL + "/bundle.tar.gz"
+	expectedLocalPath := filepath.Join(DependencyFolderPath, hash.String(url))
+
+	for i := 0; i < 2; i++ {
+		resolver, err := NewResolver(&latest.Config{}, &generated.Config{}, false, false, &testLogger{})
+		assert.NilError(t, err)
+
+		dependencies, err := resolver.Resolve([]*latest.DependencyConfig{
+			&latest.DependencyConfig{
+				Source: &latest.SourceConfig{
+					URL:      url,
+					Checksum: hex.EncodeToString(checksum[:]),
+				},
+			},
+		}, &configutil.ConfigOptions{}, false)
+		assert.NilError(t, err)
+		assert.Equal(t, len(dependencies), 1)
+		assert.Equal(t, dependencies[0].ID, url)
+		assert.Equal(t, dependencies[0].LocalPath, expectedLocalPath)
+		assert.Equal(t, dependencies[0].revision, "sha256:"+hex.EncodeToString(checksum[:]))
+
+		content, err := ioutil.ReadFile(filepath.Join(expectedLocalPath, "chart", "a.yaml"))
+		assert.NilError(t, err)
+		assert.Equal(t, string(content), "a")
+	}
+
+	// The second resolve should use the extracted dependency
+	assert.Equal(t, requests, 1)
+
+	lock, err := LoadLock(filepath.Join(dir, LockFile))
+	assert.NilError(t, err)
+	assert.Equal(t, lock.GetRevision(url), "sha256:"+hex.EncodeToString(checksum[:]))
+
+	// A wrong checksum is rejected
+	resolver, err := NewResolver(&latest.Config{}, &generated.Config{}, false, false, &testLogger{})
+	assert.NilError(t, err)
+
+	_, err = resolver.Resolve([]*latest.DependencyConfig{
+		&latest.DependencyConfig{
+			Source: &latest.SourceConfig{
+				URL:      url + "?other",
+				Checksum: "sha256:0000",
+			},
+		},
+	}, &configutil.ConfigOptions{}, false)
+	assert.ErrorContains(t, err, "Checksum mismatch for dependency "+url+"?other")
+}
+
+func TestUntarInvalidPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testUntar")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	archivePath := filepath.Join(dir, "archive.tgz")
+	err = ioutil.WriteFile(archivePath, createTestArchive(t, map[string]string{"../outside": "content"}), 0644)
+	assert.NilError(t, err)
+
+	err = untar(archivePath, filepath.Join(dir, "target"))
+	assert.Error(t, err, "archive contains invalid path ../outside")
+}
+
+func TestDownloadArchiveTimeout(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testDownload")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	stop := make(chan bool)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-stop
+	}))
+	defer server.Close()
+	defer close(stop)
+
+	clientBackup := archiveClient
+	defer func() { archiveClient = clientBackup }()
+	archiveClient = &http.Client{Timeout: 100 * time.Millisecond}
+
+	// A server that does not respond does not block the download forever
+	_, err = downloadArchive(server.URL, dir)
+	assert.ErrorContains(t, err, "Client.Timeout exceeded")
+}
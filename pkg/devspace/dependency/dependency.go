@@ -3,7 +3,8 @@ package dependency
 import (
 	"bytes"
 	"fmt"
-	"os"
+	"path/filepath"
+	"sync"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/build"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
@@ -31,7 +32,7 @@ func UpdateAll(config *latest.Config, cache *generated.Config, allowCyclic bool,
 	defer log.StopWait()
 
 	// Create a new dependency resolver
-	resolver, err := NewResolver(config, cache, allowCyclic, log)
+	resolver, err := NewResolver(config, cache, allowCyclic, false, log)
 	if err != nil {
 		return errors.Wrap(err, "new resolver")
 	}
@@ -50,13 +51,13 @@ func UpdateAll(config *latest.Config, cache *generated.Config, allowCyclic bool,
 }
 
 // BuildAll will build all dependencies if there are any
-func BuildAll(config *latest.Config, cache *generated.Config, allowCyclic, updateDependencies, skipPush, forceDeployDependencies, forceBuild, verbose bool, configOptions *configutil.ConfigOptions, logger log.Logger) error {
+func BuildAll(config *latest.Config, cache *generated.Config, allowCyclic, frozenLock, updateDependencies, skipPush, forceDeployDependencies, forceBuild, verbose bool, configOptions *configutil.ConfigOptions, logger log.Logger) error {
 	if config == nil || config.Dependencies == nil || len(config.Dependencies) == 0 {
 		return nil
 	}
 
 	// Create a new dependency resolver
-	resolver, err := NewResolver(config, cache, allowCyclic, logger)
+	resolver, err := NewResolver(config, cache, allowCyclic, frozenLock, logger)
 	if err != nil {
 		return errors.Wrap(err, "new resolver")
 	}
@@ -107,26 +108,27 @@ func BuildAll(config *latest.Config, cache *generated.Config, allowCyclic, updat
 	return nil
 }
 
-// DeployAll will deploy all dependencies if there are any
-func DeployAll(config *latest.Config, cache *generated.Config, client *kubectl.Client, allowCyclic, updateDependencies, skipPush, forceDeployDependencies, skipBuild, forceBuild, forceDeploy, verbose bool, configOptions *configutil.ConfigOptions, logger log.Logger) error {
+// DeployAll will deploy all dependencies if there are any and returns the resolved dependencies. Dependencies that do not
+// depend on each other are deployed in parallel with at most maxConcurrency dependencies at the same time
+func DeployAll(config *latest.Config, cache *generated.Config, client *kubectl.Client, allowCyclic, frozenLock, updateDependencies, skipPush, forceDeployDependencies, skipBuild, forceBuild, forceDeploy, verbose bool, maxConcurrency int, configOptions *configutil.ConfigOptions, logger log.Logger) ([]*Dependency, error) {
 	if config == nil || config.Dependencies == nil || len(config.Dependencies) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	// Create a new dependency resolver
-	resolver, err := NewResolver(config, cache, allowCyclic, logger)
+	resolver, err := NewResolver(config, cache, allowCyclic, frozenLock, logger)
 	if err != nil {
-		return errors.Wrap(err, "new resolver")
+		return nil, errors.Wrap(err, "new resolver")
 	}
 
 	// Resolve all dependencies
 	dependencies, err := resolver.Resolve(config.Dependencies, configOptions, updateDependencies)
 	if err != nil {
 		if _, ok := err.(*CyclicError); ok {
-			return errors.Errorf("%v.\n To allow cyclic dependencies run with the '%s' flag", err, ansi.Color("--allow-cyclic", "white+b"))
+			return nil, errors.Errorf("%v.\n To allow cyclic dependencies run with the '%s' flag", err, ansi.Color("--allow-cyclic", "white+b"))
 		}
 
-		return err
+		return nil, err
 	}
 
 	defer logger.StopWait()
@@ -135,35 +137,58 @@ func DeployAll(config *latest.Config, cache *generated.Config, client *kubectl.C
 		logger.Infof("To display the complete dependency deployment run with the '--verbose-dependencies' flag")
 	}
 
-	// Deploy all dependencies
-	for i := 0; i < len(dependencies); i++ {
-		var (
-			dependency       = dependencies[i]
-			buff             = &bytes.Buffer{}
-			dependencyLogger = logger
-		)
+	// Deploy one dependency after another and stream the output directly
+	if maxConcurrency <= 1 && verbose {
+		for i := 0; i < len(dependencies); i++ {
+			dependency := dependencies[i]
 
-		// If not verbose log to a stream
-		if verbose == false {
-			logger.StartWait(fmt.Sprintf("Deploying dependency %d of %d: %s", i+1, len(dependencies), dependency.ID))
-			dependencyLogger = log.NewStreamLogger(buff, logrus.InfoLevel)
-		} else {
 			logger.Infof(fmt.Sprintf("Deploying dependency %d of %d: %s", i+1, len(dependencies), dependency.ID))
+			err := dependency.Deploy(client, skipPush, forceDeployDependencies, skipBuild, forceBuild, forceDeploy, logger)
+			if err != nil {
+				return nil, errors.Errorf("Error deploying dependency %s: %v", dependency.ID, err)
+			}
+
+			logger.Donef("Deployed dependency %s", dependency.ID)
 		}
 
-		err := dependency.Deploy(client, skipPush, forceDeployDependencies, skipBuild, forceBuild, forceDeploy, dependencyLogger)
+		logger.Donef("Successfully deployed %d dependencies", len(dependencies))
+		return dependencies, nil
+	}
+
+	// The working directory is shared by the whole process, so dependencies that need it are deployed one by one
+	if maxConcurrency > 1 && needWorkingDirectory(dependencies) {
+		logger.Infof("Deploying dependencies sequentially, because at least one dependency uses hooks, custom builds or paths relative to its folder")
+		maxConcurrency = 1
+	}
+
+	// Deploy the dependencies along the dependency graph and group the output per dependency
+	progress := newProgress("Deploying", len(dependencies), logger)
+	err = runParallel(dependencies, maxConcurrency, func(dependency *Dependency) error {
+		buff := &bytes.Buffer{}
+		progress.Start(dependency.ID)
+
+		err := dependency.Deploy(client, skipPush, forceDeployDependencies, skipBuild, forceBuild, forceDeploy, log.NewStreamLogger(buff, logrus.InfoLevel))
 		if err != nil {
+			progress.Done(dependency.ID, "", "")
 			return errors.Errorf("Error deploying dependency %s: %s %v", dependency.ID, buff.String(), err)
 		}
 
-		// Prettify path if its a path deployment
-		logger.Donef("Deployed dependency %s", dependency.ID)
+		output := ""
+		if verbose {
+			output = buff.String()
+		}
+
+		progress.Done(dependency.ID, output, fmt.Sprintf("Deployed dependency %s", dependency.ID))
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	logger.StopWait()
 	logger.Donef("Successfully deployed %d dependencies", len(dependencies))
 
-	return nil
+	return dependencies, nil
 }
 
 // PurgeAll purges all dependencies in reverse order
@@ -173,7 +198,7 @@ func PurgeAll(config *latest.Config, cache *generated.Config, client *kubectl.Cl
 	}
 
 	// Create a new dependency resolver
-	resolver, err := NewResolver(config, cache, allowCyclic, logger)
+	resolver, err := NewResolver(config, cache, allowCyclic, false, logger)
 	if err != nil {
 		return err
 	}
@@ -231,6 +256,13 @@ type Dependency struct {
 
 	DependencyConfig *latest.DependencyConfig
 	DependencyCache  *generated.Config
+
+	// dependsOn holds the ids of the dependencies of this dependency
+	dependsOn []string
+	// requiredBy holds the ids of the projects that depend on this dependency
+	requiredBy []string
+	// revision is the resolved git revision of the dependency if there is any
+	revision string
 }
 
 // Build builds and pushes all defined images
@@ -242,42 +274,34 @@ func (d *Dependency) Build(skipPush, forceDependencies, forceBuild bool, log log
 	}
 
 	// Check if we skip the dependency deploy
-	if forceDependencies == false && directoryHash == d.DependencyCache.GetActive().Dependencies[d.ID] {
+	if forceDependencies == false && directoryHash == d.getCachedHash() {
 		return nil
 	}
 
-	d.DependencyCache.GetActive().Dependencies[d.ID] = directoryHash
-
-	// Switch current working directory
-	currentWorkingDirectory, err := os.Getwd()
-	if err != nil {
-		return errors.Wrap(err, "getwd")
-	}
-
-	err = os.Chdir(d.LocalPath)
-	if err != nil {
-		return errors.Wrap(err, "change working directory")
-	}
-
-	// Change back to original working directory
-	defer os.Chdir(currentWorkingDirectory)
-
-	// Check if image build is enabled
-	builtImages := make(map[string]string)
-	if d.DependencyConfig.SkipBuild == nil || *d.DependencyConfig.SkipBuild == false {
-		// Build images
-		builtImages, err = build.All(d.Config, d.GeneratedConfig.GetActive(), nil, skipPush, false, forceBuild, false, false, log)
-		if err != nil {
-			return err
-		}
+	d.setCachedHash(directoryHash)
 
-		// Save config if an image was built
-		if len(builtImages) > 0 {
-			err := generated.SaveConfig(d.GeneratedConfig)
+	err = d.inWorkingDirectory(func() error {
+		// Check if image build is enabled
+		if d.DependencyConfig.SkipBuild == nil || *d.DependencyConfig.SkipBuild == false {
+			// Build images
+			builtImages, err := build.All(d.Config, d.GeneratedConfig.GetActive(), nil, skipPush, false, forceBuild, false, false, log)
 			if err != nil {
-				return errors.Errorf("Error saving generated config: %v", err)
+				return err
+			}
+
+			// Save config if an image was built
+			if len(builtImages) > 0 {
+				err := d.saveGeneratedConfig()
+				if err != nil {
+					return errors.Errorf("Error saving generated config: %v", err)
+				}
 			}
 		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	log.Donef("Built dependency %s", d.ID)
@@ -293,25 +317,11 @@ func (d *Dependency) Deploy(client *kubectl.Client, skipPush, forceDependencies,
 	}
 
 	// Check if we skip the dependency deploy
-	if forceDependencies == false && directoryHash == d.DependencyCache.GetActive().Dependencies[d.ID] {
+	if forceDependencies == false && directoryHash == d.getCachedHash() {
 		return nil
 	}
 
-	d.DependencyCache.GetActive().Dependencies[d.ID] = directoryHash
-
-	// Switch current working directory
-	currentWorkingDirectory, err := os.Getwd()
-	if err != nil {
-		return errors.Wrap(err, "getwd")
-	}
-
-	err = os.Chdir(d.LocalPath)
-	if err != nil {
-		return errors.Wrap(err, "change working directory")
-	}
-
-	// Change back to original working directory
-	defer os.Chdir(currentWorkingDirectory)
+	d.setCachedHash(directoryHash)
 
 	// Recreate client if necessary
 	if d.DependencyConfig.Namespace != "" {
@@ -339,32 +349,34 @@ func (d *Dependency) Deploy(client *kubectl.Client, skipPush, forceDependencies,
 		return err
 	}
 
-	// Check if image build is enabled
-	builtImages := make(map[string]string)
-	if skipBuild == false && (d.DependencyConfig.SkipBuild == nil || *d.DependencyConfig.SkipBuild == false) {
-		// Build images
-		builtImages, err = build.All(d.Config, d.GeneratedConfig.GetActive(), client, skipPush, false, forceBuild, false, false, log)
-		if err != nil {
-			return err
-		}
-
-		// Save config if an image was built
-		if len(builtImages) > 0 {
-			err := generated.SaveConfig(d.GeneratedConfig)
+	err = d.inWorkingDirectory(func() error {
+		// Check if image build is enabled
+		builtImages := make(map[string]string)
+		if skipBuild == false && (d.DependencyConfig.SkipBuild == nil || *d.DependencyConfig.SkipBuild == false) {
+			// Build images
+			builtImages, err = build.All(d.Config, d.GeneratedConfig.GetActive(), client, skipPush, false, forceBuild, false, false, log)
 			if err != nil {
-				return errors.Errorf("Error saving generated config: %v", err)
+				return err
+			}
+
+			// Save config if an image was built
+			if len(builtImages) > 0 {
+				err := d.saveGeneratedConfig()
+				if err != nil {
+					return errors.Errorf("Error saving generated config: %v", err)
+				}
 			}
 		}
-	}
 
-	// Deploy all defined deployments
-	err = deploy.All(d.Config, d.GeneratedConfig.GetActive(), client, false, forceDeploy, builtImages, nil, log)
+		// Deploy all defined deployments
+		return deploy.All(d.Config, d.GeneratedConfig.GetActive(), client, false, forceDeploy, builtImages, nil, log)
+	})
 	if err != nil {
 		return err
 	}
 
 	// Save Config
-	err = generated.SaveConfig(d.GeneratedConfig)
+	err = d.saveGeneratedConfig()
 	if err != nil {
 		return errors.Errorf("Error saving generated config: %v", err)
 	}
@@ -375,21 +387,7 @@ func (d *Dependency) Deploy(client *kubectl.Client, skipPush, forceDependencies,
 
 // Purge purges the dependency
 func (d *Dependency) Purge(client *kubectl.Client, log log.Logger) error {
-	// Switch current working directory
-	currentWorkingDirectory, err := os.Getwd()
-	if err != nil {
-		return errors.Wrap(err, "getwd")
-	}
-
-	err = os.Chdir(d.LocalPath)
-	if err != nil {
-		return errors.Wrap(err, "change working directory")
-	}
-
-	defer func() {
-		// Change back to original working directory
-		os.Chdir(currentWorkingDirectory)
-	}()
+	var err error
 
 	// Recreate client if necessary
 	if d.DependencyConfig.Namespace != "" {
@@ -400,14 +398,41 @@ func (d *Dependency) Purge(client *kubectl.Client, log log.Logger) error {
 	}
 
 	// Purge the deployments
-	deploy.PurgeDeployments(d.Config, d.GeneratedConfig.GetActive(), client, nil, log)
+	d.inWorkingDirectory(func() error {
+		deploy.PurgeDeployments(d.Config, d.GeneratedConfig.GetActive(), client, nil, log)
+		return nil
+	})
 
-	err = generated.SaveConfig(d.GeneratedConfig)
+	err = d.saveGeneratedConfig()
 	if err != nil {
 		log.Errorf("Error saving generated.yaml: %v", err)
 	}
 
+	dependencyCacheMutex.Lock()
 	delete(d.DependencyCache.GetActive().Dependencies, d.ID)
+	dependencyCacheMutex.Unlock()
+
 	log.Donef("Purged dependency %s", d.ID)
 	return nil
 }
+
+// dependencyCacheMutex guards the dependency hashes in the cache of the base project
+var dependencyCacheMutex sync.Mutex
+
+func (d *Dependency) getCachedHash() string {
+	dependencyCacheMutex.Lock()
+	defer dependencyCacheMutex.Unlock()
+
+	return d.DependencyCache.GetActive().Dependencies[d.ID]
+}
+
+func (d *Dependency) setCachedHash(directoryHash string) {
+	dependencyCacheMutex.Lock()
+	defer dependencyCacheMutex.Unlock()
+
+	d.DependencyCache.GetActive().Dependencies[d.ID] = directoryHash
+}
+
+func (d *Dependency) saveGeneratedConfig() error {
+	return generated.SaveConfigToPath(d.GeneratedConfig, filepath.Join(d.LocalPath, filepath.FromSlash(generated.ConfigPath)))
+}
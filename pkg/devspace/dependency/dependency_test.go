@@ -264,7 +264,7 @@ Done Resolved 1 dependencies`,
 			Client: fake.NewSimpleClientset(),
 		}
 
-		err = DeployAll(testConfig, generatedConfig, kubeClient, testCase.allowCyclicParam, testCase.updateDependenciesParam, testCase.skipPushParam, testCase.forceDeployDependenciesParam, false, testCase.forceBuildParam, testCase.forceDeployParam, false, &configutil.ConfigOptions{}, &testLogger{})
+		_, err = DeployAll(testConfig, generatedConfig, kubeClient, testCase.allowCyclicParam, false, testCase.updateDependenciesParam, testCase.skipPushParam, testCase.forceDeployDependenciesParam, false, testCase.forceBuildParam, testCase.forceDeployParam, false, 1, &configutil.ConfigOptions{}, &testLogger{})
 
 		if testCase.expectedErr == "" {
 			assert.NilError(t, err, "Error deploying all in testCase %s", testCase.name)
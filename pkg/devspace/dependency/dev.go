@@ -0,0 +1,104 @@
+package dependency
+
+import (
+	"path/filepath"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+)
+
+// ResolveDev resolves all dependencies without updating the lock file or the generated config and returns the dependencies
+// that have dev services enabled. Use GetDevDependencies if the dependencies were already resolved (e.g. by DeployAll)
+func ResolveDev(config *latest.Config, cache *generated.Config, allowCyclic, frozenLock bool, configOptions *configutil.ConfigOptions, log log.Logger) ([]*Dependency, error) {
+	if config == nil || config.Dependencies == nil || len(config.Dependencies) == 0 {
+		return nil, nil
+	}
+
+	// Create a new dependency resolver
+	resolver, err := NewResolver(config, cache, allowCyclic, frozenLock, log)
+	if err != nil {
+		return nil, errors.Wrap(err, "new resolver")
+	}
+
+	// The dependencies were already resolved before, so there is nothing to save
+	resolver.ReadOnly = true
+
+	// Resolve all dependencies
+	dependencies, err := resolver.Resolve(config.Dependencies, configOptions, false)
+	if err != nil {
+		if _, ok := err.(*CyclicError); ok {
+			return nil, errors.Errorf("%v.\n To allow cyclic dependencies run with the '%s' flag", err, ansi.Color("--allow-cyclic", "white+b"))
+		}
+
+		return nil, err
+	}
+
+	return GetDevDependencies(dependencies), nil
+}
+
+// GetDevDependencies returns the dependencies that have dev services enabled
+func GetDevDependencies(dependencies []*Dependency) []*Dependency {
+	devDependencies := []*Dependency{}
+	for _, dependency := range dependencies {
+		if len(dependency.Config.Dev.Ports) > 0 || len(dependency.Config.Dev.Sync) > 0 || dependency.IsDevEnabled() {
+			devDependencies = append(devDependencies, dependency)
+		}
+	}
+
+	return devDependencies
+}
+
+// IsDevEnabled returns true if all dev services including logs are enabled for the dependency
+func (d *Dependency) IsDevEnabled() bool {
+	return d.DependencyConfig.Dev != nil && *d.DependencyConfig.Dev == true
+}
+
+// getDevConfig returns a dev config that only contains the enabled dev services of the dependency.
+// Sync paths are resolved relative to the dependency path and the namespace defaults to the dependency namespace
+func getDevConfig(devConfig *latest.DevConfig, dependency *latest.DependencyConfig, localPath string) *latest.DevConfig {
+	newDevConfig := &latest.DevConfig{}
+	if devConfig == nil {
+		return newDevConfig
+	}
+
+	if isEnabled(dependency.DevPorts, dependency.Dev) && devConfig.Ports != nil {
+		newDevConfig.Ports = make([]*latest.PortForwardingConfig, 0, len(devConfig.Ports))
+		for _, portConfig := range devConfig.Ports {
+			if portConfig.Namespace == "" {
+				portConfig.Namespace = dependency.Namespace
+			}
+
+			newDevConfig.Ports = append(newDevConfig.Ports, portConfig)
+		}
+	}
+
+	if isEnabled(dependency.DevSync, dependency.Dev) && devConfig.Sync != nil {
+		newDevConfig.Sync = make([]*latest.SyncConfig, 0, len(devConfig.Sync))
+		for _, syncConfig := range devConfig.Sync {
+			if syncConfig.Namespace == "" {
+				syncConfig.Namespace = dependency.Namespace
+			}
+			if filepath.IsAbs(syncConfig.LocalSubPath) == false {
+				syncConfig.LocalSubPath = filepath.Join(localPath, filepath.FromSlash(syncConfig.LocalSubPath))
+			}
+
+			newDevConfig.Sync = append(newDevConfig.Sync, syncConfig)
+		}
+	}
+
+	return newDevConfig
+}
+
+// isEnabled returns the value of the selective option if it is set and otherwise the value of the option that enables everything
+func isEnabled(selective *bool, all *bool) bool {
+	if selective != nil {
+		return *selective
+	}
+
+	return all != nil && *all == true
+}
@@ -0,0 +1,112 @@
+package dependency
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	"gotest.tools/assert"
+)
+
+type getDevConfigTestCase struct {
+	name string
+
+	dependency *latest.DependencyConfig
+
+	expectedPorts int
+	expectedSync  int
+}
+
+func TestGetDevConfig(t *testing.T) {
+	localPath, err := filepath.Abs("dependency")
+	assert.NilError(t, err)
+
+	testCases := []getDevConfigTestCase{
+		getDevConfigTestCase{
+			name:       "Disabled by default",
+			dependency: &latest.DependencyConfig{},
+		},
+		getDevConfigTestCase{
+			name:          "All dev services",
+			dependency:    &latest.DependencyConfig{Dev: ptr.Bool(true), Namespace: "dependency"},
+			expectedPorts: 1,
+			expectedSync:  2,
+		},
+		getDevConfigTestCase{
+			name:          "Only ports",
+			dependency:    &latest.DependencyConfig{DevPorts: ptr.Bool(true)},
+			expectedPorts: 1,
+		},
+		getDevConfigTestCase{
+			name:         "All dev services except ports",
+			dependency:   &latest.DependencyConfig{Dev: ptr.Bool(true), DevPorts: ptr.Bool(false)},
+			expectedSync: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		devConfig := &latest.DevConfig{
+			Ports: []*latest.PortForwardingConfig{
+				&latest.PortForwardingConfig{
+					PortMappings: []*latest.PortMapping{
+						&latest.PortMapping{LocalPort: ptr.Int(8080)},
+					},
+				},
+			},
+			Sync: []*latest.SyncConfig{
+				&latest.SyncConfig{
+					ContainerPath: "/app",
+				},
+				&latest.SyncConfig{
+					LocalSubPath:  "./src",
+					ContainerPath: "/src",
+					Namespace:     "other",
+				},
+			},
+		}
+
+		newDevConfig := getDevConfig(devConfig, testCase.dependency, localPath)
+		assert.Equal(t, len(newDevConfig.Ports), testCase.expectedPorts, "Unexpected ports in testCase %s", testCase.name)
+		assert.Equal(t, len(newDevConfig.Sync), testCase.expectedSync, "Unexpected sync in testCase %s", testCase.name)
+
+		for _, portConfig := range newDevConfig.Ports {
+			assert.Equal(t, portConfig.Namespace, testCase.dependency.Namespace, "Unexpected port namespace in testCase %s", testCase.name)
+		}
+		if len(newDevConfig.Sync) == 2 {
+			assert.Equal(t, newDevConfig.Sync[0].LocalSubPath, localPath, "Unexpected sync path in testCase %s", testCase.name)
+			assert.Equal(t, newDevConfig.Sync[0].Namespace, testCase.dependency.Namespace, "Unexpected sync namespace in testCase %s", testCase.name)
+			assert.Equal(t, newDevConfig.Sync[1].LocalSubPath, filepath.Join(localPath, "src"), "Unexpected sync path in testCase %s", testCase.name)
+			assert.Equal(t, newDevConfig.Sync[1].Namespace, "other", "Unexpected sync namespace in testCase %s", testCase.name)
+		}
+	}
+}
+
+func TestGetDevDependencies(t *testing.T) {
+	dependencies := []*Dependency{
+		&Dependency{
+			ID:               "disabled",
+			Config:           &latest.Config{Dev: &latest.DevConfig{}},
+			DependencyConfig: &latest.DependencyConfig{},
+		},
+		&Dependency{
+			ID:               "enabled",
+			Config:           &latest.Config{Dev: &latest.DevConfig{}},
+			DependencyConfig: &latest.DependencyConfig{Dev: ptr.Bool(true)},
+		},
+		&Dependency{
+			ID: "sync",
+			Config: &latest.Config{Dev: &latest.DevConfig{
+				Sync: []*latest.SyncConfig{&latest.SyncConfig{ContainerPath: "/app"}},
+			}},
+			DependencyConfig: &latest.DependencyConfig{},
+		},
+	}
+
+	devDependencies := GetDevDependencies(dependencies)
+	assert.Equal(t, len(devDependencies), 2, "Unexpected dev dependencies")
+	assert.Equal(t, devDependencies[0].ID, "enabled")
+	assert.Equal(t, devDependencies[1].ID, "sync")
+	assert.Equal(t, len(GetDevDependencies(nil)), 0, "Unexpected dev dependencies without dependencies")
+}
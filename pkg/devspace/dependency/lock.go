@@ -0,0 +1,94 @@
+package dependency
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// LockFile is the name of the file that holds the resolved revisions of all git and archive dependencies
+const LockFile = "devspace.lock"
+
+const lockFileHeader = "# This file is generated by devspace. Run `devspace update dependencies` to update it.\n"
+
+// Lock holds the resolved revisions of all git and archive dependencies
+type Lock struct {
+	Dependencies map[string]*LockedDependency `yaml:"dependencies,omitempty"`
+}
+
+// LockedDependency holds the resolved revision of a single git dependency or the checksum of an archive dependency
+type LockedDependency struct {
+	Revision string `yaml:"revision"`
+}
+
+// LoadLock loads the lock file from the given path and returns an empty lock if the file does not exist
+func LoadLock(path string) (*Lock, error) {
+	lock := &Lock{
+		Dependencies: map[string]*LockedDependency{},
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return lock, nil
+		}
+
+		return nil, err
+	}
+
+	err = yaml.Unmarshal(data, lock)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse %s", path)
+	}
+	if lock.Dependencies == nil {
+		lock.Dependencies = map[string]*LockedDependency{}
+	}
+
+	return lock, nil
+}
+
+// Save writes the lock to the given path
+func (l *Lock) Save(path string) error {
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, append([]byte(lockFileHeader), data...), 0644)
+}
+
+// GetRevision returns the locked revision of the dependency or an empty string if it is not locked
+func (l *Lock) GetRevision(id string) string {
+	if locked, ok := l.Dependencies[id]; ok && locked != nil {
+		return locked.Revision
+	}
+
+	return ""
+}
+
+// Equals checks if both locks contain the same revisions
+func (l *Lock) Equals(other *Lock) bool {
+	return reflect.DeepEqual(l.Dependencies, other.Dependencies)
+}
+
+// Diff returns the ids of all dependencies that are different in the other lock
+func (l *Lock) Diff(other *Lock) []string {
+	ids := []string{}
+	for id := range l.Dependencies {
+		if l.GetRevision(id) != other.GetRevision(id) {
+			ids = append(ids, id)
+		}
+	}
+	for id := range other.Dependencies {
+		if _, ok := l.Dependencies[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+	return ids
+}
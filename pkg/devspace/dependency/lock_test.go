@@ -0,0 +1,158 @@
+package dependency
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
+	"github.com/devspace-cloud/devspace/pkg/util/git"
+
+	"gotest.tools/assert"
+)
+
+func TestLock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testLock")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	lockPath := filepath.Join(dir, LockFile)
+
+	// Missing lock file
+	lock, err := LoadLock(lockPath)
+	assert.NilError(t, err)
+	assert.Equal(t, len(lock.Dependencies), 0)
+
+	lock.Dependencies["a"] = &LockedDependency{Revision: "1"}
+	lock.Dependencies["b"] = &LockedDependency{Revision: "2"}
+	err = lock.Save(lockPath)
+	assert.NilError(t, err)
+
+	loaded, err := LoadLock(lockPath)
+	assert.NilError(t, err)
+	assert.Equal(t, loaded.Equals(lock), true)
+	assert.Equal(t, loaded.GetRevision("a"), "1")
+	assert.Equal(t, loaded.GetRevision("c"), "")
+
+	other := &Lock{Dependencies: map[string]*LockedDependency{
+		"a": &LockedDependency{Revision: "3"},
+		"c": &LockedDependency{Revision: "4"},
+	}}
+	assert.DeepEqual(t, lock.Diff(other), []string{"a", "b", "c"})
+}
+
+func TestResolverLock(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir, err := ioutil.TempDir("", "testResolverLock")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	dir, err = filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wdBackup, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Error getting current working directory: %v", err)
+	}
+	dependencyFolderBackup := DependencyFolderPath
+	defer func() {
+		DependencyFolderPath = dependencyFolderBackup
+		os.Chdir(wdBackup)
+		os.RemoveAll(dir)
+	}()
+
+	// Create a git repository that serves as dependency
+	remote := filepath.Join(dir, "remote")
+	commit := func(message string) string {
+		err := fsutil.WriteToFile([]byte("version: "+latest.Version+"\n# "+message+"\n"), filepath.Join(remote, "devspace.yaml"))
+		assert.NilError(t, err)
+
+		for _, args := range [][]string{{"add", "-A"}, {"-c", "user.name=test", "-c", "user.email=test@test", "commit", "-m", message}} {
+			out, err := exec.Command("git", append([]string{"-C", remote}, args...)...).CombinedOutput()
+			assert.NilError(t, err, string(out))
+		}
+
+		hash, err := git.NewGitRepository(remote, "").GetHash()
+		assert.NilError(t, err)
+		return hash
+	}
+
+	err = os.MkdirAll(remote, 0755)
+	assert.NilError(t, err)
+	out, err := exec.Command("git", "init", remote).CombinedOutput()
+	assert.NilError(t, err, string(out))
+	firstCommit := commit("first")
+
+	project := filepath.Join(dir, "project")
+	err = os.MkdirAll(project, 0755)
+	assert.NilError(t, err)
+	err = os.Chdir(project)
+	assert.NilError(t, err)
+	DependencyFolderPath = filepath.Join(dir, "dependencies")
+
+	dependencies := []*latest.DependencyConfig{
+		&latest.DependencyConfig{
+			Source: &latest.SourceConfig{
+				Git: remote,
+			},
+		},
+	}
+	resolve := func(frozen, update bool) ([]*Dependency, error) {
+		resolver, err := NewResolver(&latest.Config{}, &generated.Config{}, false, frozen, &testLogger{})
+		assert.NilError(t, err)
+
+		return resolver.Resolve(dependencies, &configutil.ConfigOptions{}, update)
+	}
+
+	// Frozen without lock fails
+	_, err = resolve(true, false)
+	assert.Equal(t, err != nil && strings.Contains(err.Error(), "is not locked in devspace.lock"), true, "Unexpected error: %v", err)
+
+	// First resolve records the commit
+	resolved, err := resolve(false, false)
+	assert.NilError(t, err)
+	lock, err := LoadLock(LockFile)
+	assert.NilError(t, err)
+	assert.Equal(t, lock.GetRevision(remote), firstCommit)
+
+	// New commits are ignored until the lock is updated
+	secondCommit := commit("second")
+	os.RemoveAll(DependencyFolderPath)
+	resolved, err = resolve(true, false)
+	assert.NilError(t, err)
+	hash, err := git.NewGitRepository(resolved[0].LocalPath, "").GetHash()
+	assert.NilError(t, err)
+	assert.Equal(t, hash, firstCommit)
+
+	// Update is not allowed with a frozen lock
+	_, err = resolve(true, true)
+	assert.Error(t, err, "Cannot update dependencies, because devspace.lock is frozen")
+
+	// Update pulls the new commit and refreshes the lock
+	resolved, err = resolve(false, true)
+	assert.NilError(t, err)
+	hash, err = git.NewGitRepository(resolved[0].LocalPath, "").GetHash()
+	assert.NilError(t, err)
+	assert.Equal(t, hash, secondCommit)
+	lock, err = LoadLock(LockFile)
+	assert.NilError(t, err)
+	assert.Equal(t, lock.GetRevision(remote), secondCommit)
+
+	// Removed dependencies make a frozen lock out of date
+	dependencies = nil
+	_, err = resolve(true, false)
+	assert.Equal(t, err != nil && strings.Contains(err.Error(), "devspace.lock is out of date"), true, "Unexpected error: %v", err)
+}
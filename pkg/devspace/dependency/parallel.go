@@ -0,0 +1,158 @@
+package dependency
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+)
+
+// DefaultMaxConcurrency is the default number of dependencies that are deployed at the same time
+const DefaultMaxConcurrency = 4
+
+// runParallel executes fn for every dependency with at most maxConcurrency dependencies at the same time.
+// A dependency is only started after all of its own dependencies have finished successfully. If fn
+// returns an error, no new dependencies are started and the first error is returned after the running ones finished
+func runParallel(dependencies []*Dependency, maxConcurrency int, fn func(dependency *Dependency) error) error {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	var (
+		known      = map[string]bool{}
+		waitingFor = map[string]int{}
+		dependents = map[string][]*Dependency{}
+		ready      = []*Dependency{}
+	)
+
+	for _, dependency := range dependencies {
+		known[dependency.ID] = true
+	}
+	for _, dependency := range dependencies {
+		for _, childID := range dependency.dependsOn {
+			if known[childID] {
+				waitingFor[dependency.ID]++
+				dependents[childID] = append(dependents[childID], dependency)
+			}
+		}
+	}
+
+	// Dependencies are ordered, so leaves are started in the same order as before
+	for _, dependency := range dependencies {
+		if waitingFor[dependency.ID] == 0 {
+			ready = append(ready, dependency)
+		}
+	}
+
+	type result struct {
+		dependency *Dependency
+		err        error
+	}
+
+	var (
+		results  = make(chan result)
+		running  = 0
+		firstErr error
+	)
+
+	for {
+		// Start as many dependencies as allowed
+		for firstErr == nil && running < maxConcurrency && len(ready) > 0 {
+			next := ready[0]
+			ready = ready[1:]
+			running++
+
+			go func(dependency *Dependency) {
+				results <- result{dependency: dependency, err: fn(dependency)}
+			}(next)
+		}
+
+		if running == 0 {
+			return firstErr
+		}
+
+		finished := <-results
+		running--
+
+		if finished.err != nil {
+			if firstErr == nil {
+				firstErr = finished.err
+			}
+
+			continue
+		}
+
+		// Start dependents that are not waiting for any other dependency anymore
+		for _, dependent := range dependents[finished.dependency.ID] {
+			waitingFor[dependent.ID]--
+			if waitingFor[dependent.ID] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+}
+
+// progress prints which dependencies are currently running
+type progress struct {
+	mutex sync.Mutex
+
+	action  string
+	total   int
+	done    int
+	running map[string]bool
+
+	log log.Logger
+}
+
+func newProgress(action string, total int, log log.Logger) *progress {
+	return &progress{
+		action:  action,
+		total:   total,
+		running: map[string]bool{},
+		log:     log,
+	}
+}
+
+// Start marks the dependency as running
+func (p *progress) Start(id string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.running[id] = true
+	p.update()
+}
+
+// Done marks the dependency as finished and prints the message and output of the dependency as one block
+func (p *progress) Done(id string, output string, message string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	delete(p.running, id)
+	p.done++
+
+	p.log.StopWait()
+	if output != "" {
+		p.log.WriteString(output)
+	}
+	if message != "" {
+		p.log.Done(message)
+	}
+	p.update()
+}
+
+func (p *progress) update() {
+	if len(p.running) == 0 {
+		p.log.StopWait()
+		return
+	}
+
+	running := make([]string, 0, len(p.running))
+	for id := range p.running {
+		running = append(running, id)
+	}
+	sort.Strings(running)
+
+	p.log.StartWait(fmt.Sprintf("%s dependencies (%d of %d done): %s", p.action, p.done, p.total, strings.Join(running, ", ")))
+}
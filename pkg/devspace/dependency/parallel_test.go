@@ -0,0 +1,115 @@
+package dependency
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+)
+
+type runParallelTestCase struct {
+	name string
+
+	// dependencies maps an id to the ids it depends on. The order is the resolved order
+	dependencies   [][]string
+	maxConcurrency int
+	failing        string
+
+	expectedMaxRunning int
+	expectedStarted    int
+	expectedErr        string
+}
+
+func TestRunParallel(t *testing.T) {
+	testCases := []runParallelTestCase{
+		runParallelTestCase{
+			name:               "Sequential",
+			dependencies:       [][]string{{"a"}, {"b"}, {"c"}},
+			maxConcurrency:     1,
+			expectedMaxRunning: 1,
+			expectedStarted:    3,
+		},
+		runParallelTestCase{
+			name:               "Independent branches",
+			dependencies:       [][]string{{"a"}, {"b"}, {"c"}, {"d", "a", "b", "c"}},
+			maxConcurrency:     4,
+			expectedMaxRunning: 3,
+			expectedStarted:    4,
+		},
+		runParallelTestCase{
+			name:               "Bounded",
+			dependencies:       [][]string{{"a"}, {"b"}, {"c"}, {"d"}, {"e"}},
+			maxConcurrency:     2,
+			expectedMaxRunning: 2,
+			expectedStarted:    5,
+		},
+		runParallelTestCase{
+			name:               "Chain",
+			dependencies:       [][]string{{"a"}, {"b", "a"}, {"c", "b"}},
+			maxConcurrency:     4,
+			expectedMaxRunning: 1,
+			expectedStarted:    3,
+		},
+		runParallelTestCase{
+			name:               "Error stops dependents",
+			dependencies:       [][]string{{"a"}, {"b", "a"}, {"c", "b"}},
+			maxConcurrency:     4,
+			failing:            "a",
+			expectedMaxRunning: 1,
+			expectedStarted:    1,
+			expectedErr:        "a failed",
+		},
+	}
+
+	for _, testCase := range testCases {
+		dependencies := []*Dependency{}
+		for _, ids := range testCase.dependencies {
+			dependencies = append(dependencies, &Dependency{ID: ids[0], dependsOn: ids[1:]})
+		}
+
+		var (
+			mutex      sync.Mutex
+			running    = 0
+			maxRunning = 0
+			started    = 0
+			finished   = map[string]bool{}
+		)
+
+		err := runParallel(dependencies, testCase.maxConcurrency, func(dependency *Dependency) error {
+			mutex.Lock()
+			for _, id := range dependency.dependsOn {
+				assert.Equal(t, finished[id], true, "Dependency %s started before %s in testCase %s", dependency.ID, id, testCase.name)
+			}
+
+			running++
+			started++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mutex.Unlock()
+
+			time.Sleep(time.Millisecond * 20)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			running--
+			if dependency.ID == testCase.failing {
+				return errors.Errorf("%s failed", dependency.ID)
+			}
+
+			finished[dependency.ID] = true
+			return nil
+		})
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+		}
+
+		assert.Equal(t, maxRunning, testCase.expectedMaxRunning, "Unexpected concurrency in testCase %s", testCase.name)
+		assert.Equal(t, started, testCase.expectedStarted, "Unexpected started dependencies in testCase %s", testCase.name)
+	}
+}
@@ -0,0 +1,132 @@
+package dependency
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/helper"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/pkg/errors"
+)
+
+// resolvePaths makes all file paths in the config that are used for building and deploying absolute,
+// so that the dependency can be deployed without changing the working directory
+func resolvePaths(config *latest.Config, basePath string) {
+	for _, imageConf := range config.Images {
+		dockerfilePath, contextPath := helper.GetDockerfileAndContext(config, "", imageConf, false)
+
+		imageConf.Dockerfile = resolvePath(dockerfilePath, basePath)
+		imageConf.Context = resolvePath(contextPath, basePath)
+	}
+
+	for _, deployConfig := range config.Deployments {
+		if deployConfig.Kubectl != nil {
+			for idx, manifest := range deployConfig.Kubectl.Manifests {
+				deployConfig.Kubectl.Manifests[idx] = resolvePath(manifest, basePath)
+			}
+		}
+
+		if deployConfig.Helm != nil {
+			// Only local charts are resolved, chart names from repositories stay untouched
+			if deployConfig.Helm.Chart != nil && deployConfig.Helm.Chart.Name != "" && filepath.IsAbs(deployConfig.Helm.Chart.Name) == false {
+				chartPath := filepath.Join(basePath, filepath.FromSlash(deployConfig.Helm.Chart.Name))
+				if _, err := os.Stat(chartPath); err == nil {
+					deployConfig.Helm.Chart.Name = chartPath
+				}
+			}
+
+			if deployConfig.Helm.Chart != nil {
+				deployConfig.Helm.Chart.CertFile = resolveOptionalPath(deployConfig.Helm.Chart.CertFile, basePath)
+				deployConfig.Helm.Chart.KeyFile = resolveOptionalPath(deployConfig.Helm.Chart.KeyFile, basePath)
+				deployConfig.Helm.Chart.CAFile = resolveOptionalPath(deployConfig.Helm.Chart.CAFile, basePath)
+			}
+
+			for idx, valuesFile := range deployConfig.Helm.ValuesFiles {
+				deployConfig.Helm.ValuesFiles[idx] = resolvePath(valuesFile, basePath)
+			}
+		}
+	}
+}
+
+func resolvePath(path, basePath string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(basePath, filepath.FromSlash(path))
+}
+
+func resolveOptionalPath(path, basePath string) string {
+	if path == "" {
+		return path
+	}
+
+	return resolvePath(path, basePath)
+}
+
+// needsWorkingDirectory checks if the config contains commands or paths that are used relative to the working directory
+func needsWorkingDirectory(config *latest.Config) bool {
+	if len(config.Hooks) > 0 {
+		return true
+	}
+
+	for _, imageConf := range config.Images {
+		if imageConf.Build != nil && imageConf.Build.Custom != nil {
+			return true
+		}
+	}
+
+	for _, deployConfig := range config.Deployments {
+		// Flags and binaries of kubectl might reference local files
+		if deployConfig.Kubectl != nil && (len(deployConfig.Kubectl.Flags) > 0 || isRelativePath(deployConfig.Kubectl.CmdPath)) {
+			return true
+		}
+
+		// Local charts that were not found in the dependency folder are looked up relative to the working directory
+		if deployConfig.Helm != nil && deployConfig.Helm.Chart != nil && strings.HasPrefix(deployConfig.Helm.Chart.Name, ".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isRelativePath(path string) bool {
+	return path != "" && filepath.IsAbs(path) == false && strings.ContainsAny(path, "/\\")
+}
+
+// needWorkingDirectory checks if any of the dependencies has to be executed within its own folder
+func needWorkingDirectory(dependencies []*Dependency) bool {
+	for _, dependency := range dependencies {
+		if needsWorkingDirectory(dependency.Config) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inWorkingDirectory executes fn within the dependency folder if the dependency needs it. Because the working
+// directory is shared by the whole process, callers must not run dependencies that need it in parallel
+func (d *Dependency) inWorkingDirectory(fn func() error) error {
+	if needsWorkingDirectory(d.Config) == false {
+		return fn()
+	}
+
+	// Switch current working directory
+	currentWorkingDirectory, err := os.Getwd()
+	if err != nil {
+		return errors.Wrap(err, "getwd")
+	}
+
+	err = os.Chdir(d.LocalPath)
+	if err != nil {
+		return errors.Wrap(err, "change working directory")
+	}
+
+	// Change back to original working directory
+	defer os.Chdir(currentWorkingDirectory)
+
+	return fn()
+}
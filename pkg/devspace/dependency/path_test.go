@@ -0,0 +1,99 @@
+package dependency
+
+import (
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	"gotest.tools/assert"
+)
+
+type needsWorkingDirectoryTestCase struct {
+	name string
+
+	config *latest.Config
+
+	expected bool
+}
+
+func TestNeedsWorkingDirectory(t *testing.T) {
+	testCases := []needsWorkingDirectoryTestCase{
+		needsWorkingDirectoryTestCase{
+			name: "Resolved paths only",
+			config: &latest.Config{
+				Deployments: []*latest.DeploymentConfig{
+					&latest.DeploymentConfig{
+						Kubectl: &latest.KubectlConfig{Manifests: []string{"/dependency/kube"}, CmdPath: "kubectl"},
+					},
+					&latest.DeploymentConfig{
+						Helm: &latest.HelmConfig{Chart: &latest.ChartConfig{Name: "stable/mysql"}},
+					},
+				},
+			},
+		},
+		needsWorkingDirectoryTestCase{
+			name:     "Hooks",
+			config:   &latest.Config{Hooks: []*latest.HookConfig{&latest.HookConfig{Command: "echo"}}},
+			expected: true,
+		},
+		needsWorkingDirectoryTestCase{
+			name: "Kubectl flags",
+			config: &latest.Config{
+				Deployments: []*latest.DeploymentConfig{
+					&latest.DeploymentConfig{
+						Kubectl: &latest.KubectlConfig{Flags: []string{"--kubeconfig=kubeconfig"}},
+					},
+				},
+			},
+			expected: true,
+		},
+		needsWorkingDirectoryTestCase{
+			name: "Relative kubectl binary",
+			config: &latest.Config{
+				Deployments: []*latest.DeploymentConfig{
+					&latest.DeploymentConfig{
+						Kubectl: &latest.KubectlConfig{CmdPath: "bin/kubectl"},
+					},
+				},
+			},
+			expected: true,
+		},
+		needsWorkingDirectoryTestCase{
+			name: "Unresolved local chart",
+			config: &latest.Config{
+				Deployments: []*latest.DeploymentConfig{
+					&latest.DeploymentConfig{
+						Helm: &latest.HelmConfig{Chart: &latest.ChartConfig{Name: "./chart"}},
+					},
+				},
+			},
+			expected: true,
+		},
+		needsWorkingDirectoryTestCase{
+			name: "Custom build",
+			config: &latest.Config{
+				Images: map[string]*latest.ImageConfig{
+					"image": &latest.ImageConfig{
+						Build: &latest.BuildConfig{Custom: &latest.CustomConfig{Command: "./build"}},
+					},
+				},
+			},
+			expected: true,
+		},
+		needsWorkingDirectoryTestCase{
+			name: "Disabled build",
+			config: &latest.Config{
+				Images: map[string]*latest.ImageConfig{
+					"image": &latest.ImageConfig{
+						Build: &latest.BuildConfig{Disabled: ptr.Bool(true)},
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, needsWorkingDirectory(testCase.config), testCase.expected, "Unexpected result in testCase %s", testCase.name)
+	}
+}
@@ -45,11 +45,23 @@ type Resolver struct {
 
 	AllowCyclic bool
 
+	// FrozenLock makes resolving fail if the lock file would change
+	FrozenLock bool
+
+	// ReadOnly prevents resolving from saving the lock file and the generated config
+	ReadOnly bool
+
+	lock         *Lock
+	resolvedLock *Lock
+
+	// cycles holds the cyclic dependencies that were skipped because cyclic dependencies are allowed
+	cycles []*CyclicError
+
 	log log.Logger
 }
 
 // NewResolver creates a new resolver for resolving dependencies
-func NewResolver(baseConfig *latest.Config, baseCache *generated.Config, allowCyclic bool, log log.Logger) (*Resolver, error) {
+func NewResolver(baseConfig *latest.Config, baseCache *generated.Config, allowCyclic, frozenLock bool, log log.Logger) (*Resolver, error) {
 	var id string
 
 	basePath, err := filepath.Abs(".")
@@ -67,10 +79,12 @@ func NewResolver(baseConfig *latest.Config, baseCache *generated.Config, allowCy
 	return &Resolver{
 		DependencyGraph: NewGraph(NewNode(id, nil)),
 
+		BasePath:   basePath,
 		BaseConfig: baseConfig,
 		BaseCache:  baseCache,
 
 		AllowCyclic: allowCyclic,
+		FrozenLock:  frozenLock,
 
 		log: log,
 	}, nil
@@ -85,6 +99,18 @@ func (r *Resolver) Resolve(dependencies []*latest.DependencyConfig, configOption
 		return nil, errors.Wrap(err, "get current working directory")
 	}
 
+	// Load the lock file
+	lockPath := filepath.Join(r.BasePath, LockFile)
+	r.lock, err = LoadLock(lockPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "load lock file")
+	}
+	if update && r.FrozenLock {
+		return nil, errors.Errorf("Cannot update dependencies, because %s is frozen", LockFile)
+	}
+
+	r.resolvedLock = &Lock{Dependencies: map[string]*LockedDependency{}}
+
 	err = r.resolveRecursive(currentWorkingDirectory, r.DependencyGraph.Root.ID, dependencies, configOptions, update)
 	if err != nil {
 		if _, ok := err.(*CyclicError); ok {
@@ -96,10 +122,28 @@ func (r *Resolver) Resolve(dependencies []*latest.DependencyConfig, configOption
 
 	r.log.Donef("Resolved %d dependencies", len(r.DependencyGraph.Nodes)-1)
 
+	// Save the lock file if revisions were added, changed or removed
+	if r.lock.Equals(r.resolvedLock) == false {
+		if r.FrozenLock {
+			return nil, errors.Errorf("%s is out of date for the dependencies:\n- %s\nPlease run `devspace update dependencies` and commit %s", LockFile, strings.Join(r.lock.Diff(r.resolvedLock), "\n- "), LockFile)
+		}
+
+		if r.ReadOnly == false {
+			err = r.resolvedLock.Save(lockPath)
+			if err != nil {
+				return nil, errors.Wrap(err, "save lock file")
+			}
+
+			r.log.Donef("Updated %s", LockFile)
+		}
+	}
+
 	// Save generated
-	err = generated.SaveConfig(r.BaseCache)
-	if err != nil {
-		return nil, err
+	if r.ReadOnly == false {
+		err = generated.SaveConfig(r.BaseCache)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return r.buildDependencyQueue()
@@ -108,6 +152,21 @@ func (r *Resolver) Resolve(dependencies []*latest.DependencyConfig, configOption
 func (r *Resolver) buildDependencyQueue() ([]*Dependency, error) {
 	retDependencies := make([]*Dependency, 0, len(r.DependencyGraph.Nodes)-1)
 
+	// Remember the edges of the graph before the nodes are removed
+	for _, node := range r.DependencyGraph.Nodes {
+		if dependency, ok := node.Data.(*Dependency); ok {
+			dependency.dependsOn = make([]string, 0, len(node.childs))
+			for _, child := range node.childs {
+				dependency.dependsOn = append(dependency.dependsOn, child.ID)
+			}
+
+			dependency.requiredBy = make([]string, 0, len(node.parents))
+			for _, parent := range node.parents {
+				dependency.requiredBy = append(dependency.requiredBy, parent.ID)
+			}
+		}
+	}
+
 	for len(r.DependencyGraph.Nodes) > 1 {
 		next := r.DependencyGraph.GetNextLeaf(r.DependencyGraph.Root)
 		if next == r.DependencyGraph.Root {
@@ -133,11 +192,13 @@ func (r *Resolver) resolveRecursive(basePath, parentID string, dependencies []*l
 		if _, ok := r.DependencyGraph.Nodes[ID]; ok {
 			err := r.DependencyGraph.AddEdge(parentID, ID)
 			if err != nil {
-				if _, ok := err.(*CyclicError); ok {
+				if cyclicErr, ok := err.(*CyclicError); ok {
 					// Check if cyclic dependencies are allowed
 					if !r.AllowCyclic {
 						return err
 					}
+
+					r.cycles = append(r.cycles, cyclicErr)
 				} else {
 					return err
 				}
@@ -170,9 +231,10 @@ func (r *Resolver) resolveRecursive(basePath, parentID string, dependencies []*l
 
 func (r *Resolver) resolveDependency(basePath string, dependency *latest.DependencyConfig, configOptions *configutil.ConfigOptions, update bool) (*Dependency, error) {
 	var (
-		ID        = r.getDependencyID(basePath, dependency)
-		localPath string
-		err       error
+		ID               = r.getDependencyID(basePath, dependency)
+		localPath        string
+		resolvedRevision string
+		err              error
 	)
 
 	// Resolve source
@@ -182,14 +244,24 @@ func (r *Resolver) resolveDependency(basePath string, dependency *latest.Depende
 		os.MkdirAll(DependencyFolderPath, 0755)
 		localPath = filepath.Join(DependencyFolderPath, hash.String(ID))
 
+		lockedRevision := r.lock.GetRevision(ID)
+		if lockedRevision == "" && r.FrozenLock {
+			return nil, errors.Errorf("Dependency %s is not locked in %s. Please run `devspace update dependencies` and commit %s", ID, LockFile, LockFile)
+		}
+
 		// Check if dependency exists
 		_, err := os.Stat(localPath)
-		if err != nil {
+		if err != nil && lockedRevision == "" {
 			update = true
 		}
 
 		// Update dependency
-		if update {
+		if lockedRevision != "" && update == false {
+			err = checkoutLockedRevision(localPath, gitPath, lockedRevision)
+			if err != nil {
+				return nil, errors.Wrapf(err, "checkout locked revision %s", lockedRevision)
+			}
+		} else if update {
 			var (
 				gitRepo  = git.NewGitRepository(localPath, gitPath)
 				tag      = dependency.Source.Tag
@@ -211,11 +283,30 @@ func (r *Resolver) resolveDependency(basePath string, dependency *latest.Depende
 
 			r.log.Donef("Pulled %s", ID)
 		}
+
+		// Record the resolved revision
+		revision, err := git.NewGitRepository(localPath, gitPath).GetHash()
+		if err != nil {
+			return nil, errors.Wrap(err, "get revision")
+		}
+
+		r.resolvedLock.Dependencies[ID] = &LockedDependency{Revision: revision}
+		resolvedRevision = revision
 	} else if dependency.Source.Path != "" {
 		localPath, err = filepath.Abs(filepath.Join(basePath, filepath.FromSlash(dependency.Source.Path)))
 		if err != nil {
 			return nil, errors.Wrap(err, "filepath absolute")
 		}
+
+		// Local dependencies might be git repositories as well
+		resolvedRevision, _ = git.NewGitRepository(localPath, "").GetHash()
+	} else if dependency.Source.URL != "" || dependency.Source.OCI != "" {
+		localPath, resolvedRevision, err = r.resolveArchive(ID, dependency.Source, update)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, errors.New("dependency source is missing: please specify source.git, source.path, source.url or source.oci")
 	}
 
 	if dependency.Source.SubPath != "" {
@@ -229,6 +320,12 @@ func (r *Resolver) resolveDependency(basePath string, dependency *latest.Depende
 	}
 
 	cloned.Profile = dependency.Profile
+	cloned.Overrides = dependency.Overrides
+
+	// Variables of the dependency config take precedence over the --var flags
+	for _, variable := range dependency.Vars {
+		cloned.Vars = append(cloned.Vars, strings.TrimSpace(variable.Name)+"="+variable.Value)
+	}
 
 	// Load config
 	dConfig, err := configutil.GetConfigFromPath(r.BaseCache, localPath, cloned, log.Discard)
@@ -236,8 +333,11 @@ func (r *Resolver) resolveDependency(basePath string, dependency *latest.Depende
 		return nil, errors.Errorf("Error loading config for dependency %s: %v", ID, err)
 	}
 
-	// Override complete dev config
-	dConfig.Dev = &latest.DevConfig{}
+	// Override dev config with the enabled dev services
+	dConfig.Dev = getDevConfig(dConfig.Dev, dependency, localPath)
+
+	// Make paths absolute, because the dependency is deployed from the base path
+	resolvePaths(dConfig, localPath)
 
 	// Check if we should skip building
 	if dependency.SkipBuild != nil && *dependency.SkipBuild == true {
@@ -262,9 +362,29 @@ func (r *Resolver) resolveDependency(basePath string, dependency *latest.Depende
 
 		DependencyConfig: dependency,
 		DependencyCache:  r.BaseCache,
+
+		revision: resolvedRevision,
 	}, nil
 }
 
+// checkoutLockedRevision makes sure the repository in localPath is checked out at the given revision
+func checkoutLockedRevision(localPath, gitPath, revision string) error {
+	gitRepo := git.NewGitRepository(localPath, gitPath)
+
+	currentRevision, err := gitRepo.GetHash()
+	if err == nil && currentRevision == revision {
+		return nil
+	}
+
+	// Clone or fetch the repository, because the revision might not be there yet
+	err = gitRepo.Update(false)
+	if err != nil {
+		return errors.Wrap(err, "pull repo")
+	}
+
+	return gitRepo.Checkout("", "", revision)
+}
+
 var authRegEx = regexp.MustCompile("^(https?:\\/\\/)[^:]+:[^@]+@(.*)$")
 
 func (r *Resolver) getDependencyID(basePath string, dependency *latest.DependencyConfig) string {
@@ -305,6 +425,22 @@ func (r *Resolver) getDependencyID(basePath string, dependency *latest.Dependenc
 		}
 
 		return filePath
+	} else if dependency.Source.URL != "" || dependency.Source.OCI != "" {
+		id := strings.TrimSpace(dependency.Source.OCI)
+		if dependency.Source.URL != "" {
+			// Erase authentication credentials
+			id = authRegEx.ReplaceAllString(strings.TrimSpace(dependency.Source.URL), "$1$2")
+		}
+
+		if dependency.Source.SubPath != "" {
+			id += ":" + dependency.Source.SubPath
+		}
+
+		if dependency.Profile != "" {
+			id += " - profile " + dependency.Profile
+		}
+
+		return id
 	}
 
 	return ""
@@ -110,7 +110,7 @@ func TestResolver(t *testing.T) {
 					LocalPath: filepath.Join(DependencyFolderPath, "84e3f5121aa5a99b3d26752f40e3935f494312ad82d0e85afc9b6e23c762c705", "mysubpath"),
 				},
 			},
-			expectedLog: "\nInfo Start resolving dependencies\nDone Pulled https://github.com/devspace-cloud/example-dependency.git@f8b2aa8cf8ac03238a28e8f78382b214d619893f:mysubpath\nDone Resolved 1 dependencies",
+			expectedLog: "\nInfo Start resolving dependencies\nDone Pulled https://github.com/devspace-cloud/example-dependency.git@f8b2aa8cf8ac03238a28e8f78382b214d619893f:mysubpath\nDone Resolved 1 dependencies\nDone Updated devspace.lock",
 		},
 		resolverTestCase{
 			name: "Cyclic allowed dependency",
@@ -181,7 +181,7 @@ func TestResolver(t *testing.T) {
 
 		testConfig := &latest.Config{}
 		generatedConfig := &generated.Config{}
-		testResolver, err := NewResolver(testConfig, generatedConfig, testCase.allowCyclic, &testLogger{})
+		testResolver, err := NewResolver(testConfig, generatedConfig, testCase.allowCyclic, false, &testLogger{})
 		assert.NilError(t, err, "Error creating a resolver in testCase %s", testCase.name)
 
 		dependencies, err := testResolver.Resolve(testCase.dependencyTasks, &configutil.ConfigOptions{}, testCase.updateParam)
@@ -202,6 +202,7 @@ func TestResolver(t *testing.T) {
 			assert.NilError(t, err, "Error removing file in testCase %s", testCase.name)
 		}
 		os.RemoveAll(DependencyFolderPath) //No error catch because it doesn't need to exist
+		os.Remove(LockFile)
 
 		assert.Equal(t, logOutput, testCase.expectedLog, "Unexpected output in testCase %s", testCase.name)
 
@@ -0,0 +1,128 @@
+package diff
+
+import (
+	"encoding/base64"
+
+	"github.com/devspace-cloud/devspace/pkg/util/hash"
+)
+
+// serverMetadataFields are metadata fields that are set by the api server and should not show up in a diff
+var serverMetadataFields = []string{"uid", "resourceVersion", "generation", "creationTimestamp", "selfLink", "managedFields"}
+
+// serverAnnotations are annotations that are set by kubectl or controllers and should not show up in a diff
+var serverAnnotations = []string{"kubectl.kubernetes.io/last-applied-configuration", "deployment.kubernetes.io/revision"}
+
+// Clean returns a copy of the object without status and server side metadata. Secret values are masked
+func Clean(object map[string]interface{}) map[string]interface{} {
+	cleaned := copyValue(object).(map[string]interface{})
+	delete(cleaned, "status")
+
+	if metadata, ok := cleaned["metadata"].(map[string]interface{}); ok {
+		for _, field := range serverMetadataFields {
+			delete(metadata, field)
+		}
+
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			for _, annotation := range serverAnnotations {
+				delete(annotations, annotation)
+			}
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+	}
+
+	if kind, _ := cleaned["kind"].(string); kind == "Secret" {
+		maskSecret(cleaned)
+	}
+
+	return cleaned
+}
+
+// maskSecret moves stringData into data and replaces every value with its hash
+func maskSecret(secret map[string]interface{}) {
+	data, ok := secret["data"].(map[string]interface{})
+	if ok == false {
+		data = map[string]interface{}{}
+	}
+
+	if stringData, ok := secret["stringData"].(map[string]interface{}); ok {
+		for key, value := range stringData {
+			if str, ok := value.(string); ok {
+				data[key] = base64.StdEncoding.EncodeToString([]byte(str))
+			}
+		}
+
+		delete(secret, "stringData")
+	}
+
+	for key, value := range data {
+		if str, ok := value.(string); ok {
+			data[key] = "(masked " + hash.String(str)[:8] + ")"
+		}
+	}
+
+	if len(data) > 0 {
+		secret["data"] = data
+	}
+}
+
+// Prune removes all fields from live that are not specified in desired, so that
+// defaulted fields of the api server do not show up in the diff
+func Prune(live interface{}, desired interface{}) interface{} {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		liveMap, ok := live.(map[string]interface{})
+		if ok == false {
+			return live
+		}
+
+		pruned := map[string]interface{}{}
+		for key, value := range desiredValue {
+			if liveValue, ok := liveMap[key]; ok {
+				pruned[key] = Prune(liveValue, value)
+			}
+		}
+
+		return pruned
+	case []interface{}:
+		liveSlice, ok := live.([]interface{})
+		if ok == false {
+			return live
+		}
+
+		pruned := make([]interface{}, 0, len(liveSlice))
+		for idx, liveValue := range liveSlice {
+			if idx < len(desiredValue) {
+				pruned = append(pruned, Prune(liveValue, desiredValue[idx]))
+			} else {
+				pruned = append(pruned, liveValue)
+			}
+		}
+
+		return pruned
+	}
+
+	return live
+}
+
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			copied[key] = copyValue(val)
+		}
+
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for idx, val := range v {
+			copied[idx] = copyValue(val)
+		}
+
+		return copied
+	}
+
+	return value
+}
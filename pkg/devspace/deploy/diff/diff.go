@@ -0,0 +1,238 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// Status describes how a rendered object differs from the live object in the cluster
+type Status string
+
+const (
+	// StatusCreated means the object does not exist in the cluster yet
+	StatusCreated Status = "created"
+	// StatusChanged means the object exists but would be changed
+	StatusChanged Status = "changed"
+	// StatusUnchanged means the object exists and would not be changed
+	StatusUnchanged Status = "unchanged"
+	// StatusUnknown means the object could not be compared
+	StatusUnknown Status = "unknown"
+)
+
+// Result holds the diff result of a single object
+type Result struct {
+	Kind      string
+	Namespace string
+	Name      string
+
+	Status Status
+	Diff   string
+}
+
+// Differ compares rendered manifests with the objects that currently exist in the cluster
+type Differ struct {
+	dynamic dynamic.Interface
+	mapper  meta.RESTMapper
+}
+
+// NewDiffer creates a new differ for the given kube client
+func NewDiffer(client *kubectl.Client) (*Differ, error) {
+	dynamicClient, err := dynamic.NewForConfig(client.RestConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "create dynamic client")
+	}
+
+	groupResources, err := restmapper.GetAPIGroupResources(client.Client.Discovery())
+	if err != nil {
+		return nil, errors.Wrap(err, "discover api resources")
+	}
+
+	return &Differ{
+		dynamic: dynamicClient,
+		mapper:  restmapper.NewDiscoveryRESTMapper(groupResources),
+	}, nil
+}
+
+// Diff compares every object in manifests with its live counterpart. Objects without a namespace are looked up in the given namespace
+func (d *Differ) Diff(manifests, namespace string) ([]*Result, error) {
+	objects, err := ParseObjects(manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*Result, 0, len(objects))
+	for _, object := range objects {
+		result, err := d.diffObject(object, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (d *Differ) diffObject(object map[string]interface{}, namespace string) (*Result, error) {
+	apiVersion, _ := object["apiVersion"].(string)
+	kind, _ := object["kind"].(string)
+	metadata, _ := object["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	if objectNamespace, ok := metadata["namespace"].(string); ok && objectNamespace != "" {
+		namespace = objectNamespace
+	}
+
+	result := &Result{
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+	}
+
+	desired := Clean(object)
+	desiredYaml, err := toYaml(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
+	mapping, err := d.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		result.Status = StatusUnknown
+		result.Diff = fmt.Sprintf("Unable to find resource for %s: %v", apiVersion, err)
+		return result, nil
+	}
+
+	var resource dynamic.ResourceInterface = d.dynamic.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		resource = d.dynamic.Resource(mapping.Resource).Namespace(namespace)
+	} else {
+		result.Namespace = ""
+	}
+
+	live, err := resource.Get(name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) == false {
+			return nil, errors.Wrapf(err, "get %s %s", kind, name)
+		}
+
+		result.Status = StatusCreated
+		result.Diff = Lines("", desiredYaml)
+		return result, nil
+	}
+
+	liveObject, err := normalize(live.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	liveYaml, err := toYaml(Prune(Clean(liveObject), desired))
+	if err != nil {
+		return nil, err
+	}
+
+	if liveYaml == desiredYaml {
+		result.Status = StatusUnchanged
+		return result, nil
+	}
+
+	result.Status = StatusChanged
+	result.Diff = Lines(liveYaml, desiredYaml)
+	return result, nil
+}
+
+// Print writes the results that are not unchanged in a human readable way to out
+func Print(results []*Result, out io.Writer) error {
+	for _, result := range results {
+		if result.Status == StatusUnchanged {
+			continue
+		}
+
+		target := result.Name
+		if result.Namespace != "" {
+			target = result.Namespace + "/" + result.Name
+		}
+
+		_, err := fmt.Fprintf(out, "%s %s %s\n%s\n", ansi.Color(result.Kind, "white+b"), ansi.Color(target, "white+b"), "("+string(result.Status)+")", strings.TrimRight(result.Diff, "\n"))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ParseObjects parses all kubernetes objects from the given multi document yaml. Lists are expanded into their items
+func ParseObjects(manifests string) ([]map[string]interface{}, error) {
+	objects := []map[string]interface{}{}
+	decoder := utilyaml.NewYAMLOrJSONDecoder(strings.NewReader(manifests), 4096)
+
+	for {
+		object := map[string]interface{}{}
+		err := decoder.Decode(&object)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrap(err, "parse manifests")
+		}
+
+		if len(object) == 0 {
+			continue
+		}
+
+		if kind, _ := object["kind"].(string); strings.HasSuffix(kind, "List") {
+			if items, ok := object["items"].([]interface{}); ok {
+				for _, item := range items {
+					if itemObject, ok := item.(map[string]interface{}); ok {
+						objects = append(objects, itemObject)
+					}
+				}
+
+				continue
+			}
+		}
+
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+// normalize converts the object into the same types a json decoded object would have
+func normalize(object map[string]interface{}) (map[string]interface{}, error) {
+	out, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := map[string]interface{}{}
+	err = json.Unmarshal(out, &normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	return normalized, nil
+}
+
+func toYaml(object interface{}) (string, error) {
+	out, err := yaml.Marshal(object)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal yaml")
+	}
+
+	return string(out), nil
+}
@@ -0,0 +1,127 @@
+package diff
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mgutz/ansi"
+	"gotest.tools/assert"
+)
+
+type pruneTestCase struct {
+	name string
+
+	live    string
+	desired string
+
+	expected string
+}
+
+func TestPrune(t *testing.T) {
+	testCases := []pruneTestCase{
+		pruneTestCase{
+			name:     "Remove defaulted fields",
+			live:     `{"spec": {"replicas": 1, "revisionHistoryLimit": 10}}`,
+			desired:  `{"spec": {"replicas": 2}}`,
+			expected: `{"spec": {"replicas": 1}}`,
+		},
+		pruneTestCase{
+			name:     "Keep missing fields missing",
+			live:     `{"spec": {}}`,
+			desired:  `{"spec": {"replicas": 2}}`,
+			expected: `{"spec": {}}`,
+		},
+		pruneTestCase{
+			name:     "Prune list items by index",
+			live:     `{"containers": [{"name": "a", "imagePullPolicy": "Always"}, {"name": "b"}]}`,
+			desired:  `{"containers": [{"name": "a"}]}`,
+			expected: `{"containers": [{"name": "a"}, {"name": "b"}]}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		live, desired, expected := map[string]interface{}{}, map[string]interface{}{}, map[string]interface{}{}
+		assert.NilError(t, json.Unmarshal([]byte(testCase.live), &live))
+		assert.NilError(t, json.Unmarshal([]byte(testCase.desired), &desired))
+		assert.NilError(t, json.Unmarshal([]byte(testCase.expected), &expected))
+
+		prunedYaml, err := toYaml(Prune(live, desired))
+		assert.NilError(t, err)
+		expectedYaml, err := toYaml(expected)
+		assert.NilError(t, err)
+
+		assert.Equal(t, prunedYaml, expectedYaml, "Unexpected result in test case %s", testCase.name)
+	}
+}
+
+func TestClean(t *testing.T) {
+	object := map[string]interface{}{
+		"kind": "Secret",
+		"metadata": map[string]interface{}{
+			"name":            "test",
+			"uid":             "1234",
+			"resourceVersion": "1",
+			"annotations": map[string]interface{}{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+		},
+		"stringData": map[string]interface{}{
+			"password": "secret",
+		},
+		"status": map[string]interface{}{},
+	}
+
+	cleaned := Clean(object)
+	out, err := toYaml(cleaned)
+	assert.NilError(t, err)
+
+	assert.Equal(t, strings.Contains(out, "secret\n"), false, "Secret value was not masked")
+	assert.Equal(t, strings.Contains(out, "uid"), false, "Server metadata was not removed")
+	assert.Equal(t, strings.Contains(out, "annotations"), false, "Empty annotations were not removed")
+	assert.Equal(t, strings.Contains(out, "status"), false, "Status was not removed")
+
+	// The original object should stay untouched
+	assert.Equal(t, object["metadata"].(map[string]interface{})["uid"], "1234")
+}
+
+func TestParseObjects(t *testing.T) {
+	objects, err := ParseObjects(`# Source: test
+apiVersion: v1
+kind: Service
+metadata:
+  name: a
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: b
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: c
+---
+`)
+	assert.NilError(t, err)
+	assert.Equal(t, len(objects), 3)
+	assert.Equal(t, objects[2]["kind"], "ConfigMap")
+}
+
+func TestLines(t *testing.T) {
+	from := "a: 1\nb: 2\nc: 3\nd: 4\ne: 5\nf: 6\ng: 7\nh: 8\n"
+	to := "a: 1\nb: 2\nc: 3\nd: 4\ne: 5\nf: 6\ng: 7\nh: 9\n"
+
+	expected := []string{
+		ansi.Color("  ...", "cyan"),
+		"  e: 5",
+		"  f: 6",
+		"  g: 7",
+		ansi.Color("- h: 8", "red"),
+		ansi.Color("+ h: 9", "green"),
+	}
+
+	assert.Equal(t, Lines(from, to), strings.Join(expected, "\n")+"\n")
+}
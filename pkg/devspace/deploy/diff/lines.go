@@ -0,0 +1,78 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/mgutz/ansi"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// contextLines is the amount of unchanged lines that are printed around a change
+const contextLines = 3
+
+type line struct {
+	operation diffmatchpatch.Operation
+	text      string
+}
+
+// Lines returns a colored line based diff between from and to that only contains the changed lines and their context
+func Lines(from, to string) string {
+	lines := diffLines(from, to)
+	show := make([]bool, len(lines))
+
+	for idx, l := range lines {
+		if l.operation == diffmatchpatch.DiffEqual {
+			continue
+		}
+
+		for i := idx - contextLines; i <= idx+contextLines; i++ {
+			if i >= 0 && i < len(lines) {
+				show[i] = true
+			}
+		}
+	}
+
+	out := []string{}
+	for idx, l := range lines {
+		if show[idx] == false {
+			if idx == 0 || show[idx-1] {
+				out = append(out, ansi.Color("  ...", "cyan"))
+			}
+
+			continue
+		}
+
+		switch l.operation {
+		case diffmatchpatch.DiffInsert:
+			out = append(out, ansi.Color("+ "+l.text, "green"))
+		case diffmatchpatch.DiffDelete:
+			out = append(out, ansi.Color("- "+l.text, "red"))
+		default:
+			out = append(out, "  "+l.text)
+		}
+	}
+
+	return strings.Join(out, "\n") + "\n"
+}
+
+func diffLines(from, to string) []line {
+	dmp := diffmatchpatch.New()
+	fromChars, toChars, lineArray := dmp.DiffLinesToChars(from, to)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(fromChars, toChars, false), lineArray)
+
+	lines := []line{}
+	for _, diff := range diffs {
+		for _, text := range strings.SplitAfter(diff.Text, "\n") {
+			if text == "" {
+				continue
+			}
+
+			lines = append(lines, line{
+				operation: diff.Type,
+				text:      strings.TrimSuffix(text, "\n"),
+			})
+		}
+	}
+
+	return lines
+}
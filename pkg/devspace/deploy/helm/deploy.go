@@ -2,6 +2,7 @@ package helm
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -108,15 +109,76 @@ func (d *DeployConfig) Deploy(cache *generated.CacheConfig, forceDeploy bool, bu
 
 func (d *DeployConfig) internalDeploy(cache *generated.CacheConfig, forceDeploy bool, builtImages map[string]string) (bool, error) {
 	var (
-		releaseName     = d.DeploymentConfig.Name
+		releaseName      = d.DeploymentConfig.Name
+		releaseNamespace = d.DeploymentConfig.Namespace
+	)
+
+	overwriteValues, shouldRedeploy, err := d.getDeploymentValues(cache, builtImages)
+	if err != nil {
+		return false, err
+	}
+
+	// Deployment is not necessary
+	if forceDeploy == false && shouldRedeploy == false {
+		return false, nil
+	}
+
+	d.Log.StartWait(fmt.Sprintf("Deploying chart %s (%s) with helm", d.DeploymentConfig.Helm.Chart.Name, d.DeploymentConfig.Name))
+	defer d.Log.StopWait()
+
+	// Deploy chart
+	appRelease, err := d.Helm.InstallChart(releaseName, releaseNamespace, &overwriteValues, d.DeploymentConfig.Helm)
+	if err != nil {
+		return false, errors.Errorf("Unable to deploy helm chart: %v\nRun `%s` and `%s` to recreate the chart", err, ansi.Color("devspace purge -d "+d.DeploymentConfig.Name, "white+b"), ansi.Color("devspace deploy", "white+b"))
+	}
+
+	// Print revision
+	if appRelease != nil {
+		releaseRevision := int(appRelease.Version)
+		d.Log.Donef("Deployed helm chart (Release revision: %d)", releaseRevision)
+	} else {
+		d.Log.Done("Deployed helm chart")
+	}
+
+	return true, nil
+}
+
+// Render renders the chart with the merged values locally and writes the resulting manifests to out
+func (d *DeployConfig) Render(cache *generated.CacheConfig, builtImages map[string]string, out io.Writer) error {
+	var err error
+
+	// Rendering does not need tiller, so we only create a template client if necessary
+	if d.Helm == nil {
+		d.Helm, err = helm.NewTemplateClient(d.config, d.Kube, d.Log)
+		if err != nil {
+			return errors.Errorf("Error creating helm client: %v", err)
+		}
+	}
+
+	overwriteValues, _, err := d.getDeploymentValues(cache, builtImages)
+	if err != nil {
+		return err
+	}
+
+	manifests, err := d.Helm.Template(d.DeploymentConfig.Name, d.DeploymentConfig.Namespace, &overwriteValues, d.DeploymentConfig.Helm)
+	if err != nil {
+		return errors.Errorf("Unable to render helm chart: %v", err)
+	}
+
+	_, err = out.Write([]byte(manifests))
+	return err
+}
+
+// getDeploymentValues merges the chart values, the values files and the inline values and replaces the image tags.
+// The returned bool indicates if an image was rebuilt that is referenced in the values
+func (d *DeployConfig) getDeploymentValues(cache *generated.CacheConfig, builtImages map[string]string) (map[interface{}]interface{}, bool, error) {
+	var (
 		chartPath       = d.DeploymentConfig.Helm.Chart.Name
 		chartValuesPath = filepath.Join(chartPath, "values.yaml")
 		overwriteValues = map[interface{}]interface{}{}
+		shouldRedeploy  = false
 	)
 
-	// Get release namespace
-	releaseNamespace := d.DeploymentConfig.Namespace
-
 	// Check if its a local chart
 	_, err := os.Stat(chartValuesPath)
 	if err == nil {
@@ -125,7 +187,7 @@ func (d *DeployConfig) internalDeploy(cache *generated.CacheConfig, forceDeploy
 		if err == nil {
 			err := yamlutil.ReadYamlFromFile(chartValuesPath, overwriteValues)
 			if err != nil {
-				return false, errors.Errorf("Couldn't deploy chart, error reading from chart values %s: %v", chartValuesPath, err)
+				return nil, false, errors.Errorf("Couldn't deploy chart, error reading from chart values %s: %v", chartValuesPath, err)
 			}
 		}
 	}
@@ -135,7 +197,7 @@ func (d *DeployConfig) internalDeploy(cache *generated.CacheConfig, forceDeploy
 		for _, overridePath := range d.DeploymentConfig.Helm.ValuesFiles {
 			overwriteValuesPath, err := filepath.Abs(overridePath)
 			if err != nil {
-				return false, errors.Errorf("Error retrieving absolute path from %s: %v", overridePath, err)
+				return nil, false, errors.Errorf("Error retrieving absolute path from %s: %v", overridePath, err)
 			}
 
 			overwriteValuesFromPath := map[interface{}]interface{}{}
@@ -156,35 +218,10 @@ func (d *DeployConfig) internalDeploy(cache *generated.CacheConfig, forceDeploy
 	// Add devspace specific values
 	if d.DeploymentConfig.Helm.ReplaceImageTags == nil || *d.DeploymentConfig.Helm.ReplaceImageTags == true {
 		// Replace image names
-		shouldRedeploy := replaceContainerNames(overwriteValues, cache, d.config.Images, builtImages)
-		if forceDeploy == false && shouldRedeploy {
-			forceDeploy = true
-		}
+		shouldRedeploy = replaceContainerNames(overwriteValues, cache, d.config.Images, builtImages)
 	}
 
-	// Deployment is not necessary
-	if forceDeploy == false {
-		return false, nil
-	}
-
-	d.Log.StartWait(fmt.Sprintf("Deploying chart %s (%s) with helm", d.DeploymentConfig.Helm.Chart.Name, d.DeploymentConfig.Name))
-	defer d.Log.StopWait()
-
-	// Deploy chart
-	appRelease, err := d.Helm.InstallChart(releaseName, releaseNamespace, &overwriteValues, d.DeploymentConfig.Helm)
-	if err != nil {
-		return false, errors.Errorf("Unable to deploy helm chart: %v\nRun `%s` and `%s` to recreate the chart", err, ansi.Color("devspace purge -d "+d.DeploymentConfig.Name, "white+b"), ansi.Color("devspace deploy", "white+b"))
-	}
-
-	// Print revision
-	if appRelease != nil {
-		releaseRevision := int(appRelease.Version)
-		d.Log.Donef("Deployed helm chart (Release revision: %d)", releaseRevision)
-	} else {
-		d.Log.Done("Deployed helm chart")
-	}
-
-	return true, nil
+	return overwriteValues, shouldRedeploy, nil
 }
 
 func replaceContainerNames(overwriteValues map[interface{}]interface{}, cache *generated.CacheConfig, imagesConf map[string]*latest.ImageConfig, builtImages map[string]string) bool {
@@ -486,3 +486,47 @@ func TestReplaceImageNames(t *testing.T) {
 		t.Fatalf("Replace failed: Got\n %s\n, but expected\n %s", gotYaml, expectedYaml)
 	}
 }
+
+func TestRender(t *testing.T) {
+	deployConfig := &latest.DeploymentConfig{
+		Name: "test-deployment",
+		Helm: &latest.HelmConfig{
+			Chart: &latest.ChartConfig{
+				Name: "does-not-exist",
+			},
+			Values: map[interface{}]interface{}{
+				"image": "nginx",
+			},
+		},
+	}
+	testConfig := &latest.Config{
+		Deployments: []*latest.DeploymentConfig{deployConfig},
+		Images: map[string]*latest.ImageConfig{
+			"default": &latest.ImageConfig{
+				Image: "nginx",
+			},
+		},
+	}
+	cache := &generated.CacheConfig{
+		Images: map[string]*generated.ImageCache{
+			"default": &generated.ImageCache{
+				ImageName: "nginx",
+				Tag:       "1.15",
+			},
+		},
+	}
+
+	kubeClient := &kubectl.Client{
+		Client: fake.NewSimpleClientset(),
+	}
+
+	helm, err := New(testConfig, kubeClient, deployConfig, log.GetInstance())
+	assert.NilError(t, err)
+	helm.Helm = otherhelmpackage.NewFakeClient(kubeClient.Client, "")
+
+	// The fake client renders the values, so we can check if the image tag was replaced
+	out := &strings.Builder{}
+	err = helm.Render(cache, nil, out)
+	assert.NilError(t, err)
+	assert.Equal(t, out.String(), "image: nginx:1.15\n")
+}
@@ -1,6 +1,8 @@
 package deploy
 
 import (
+	"io"
+
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 )
 
@@ -9,6 +11,9 @@ type Interface interface {
 	Status() (*StatusResult, error)
 	Deploy(cache *generated.CacheConfig, forceDeploy bool, builtImages map[string]string) (bool, error)
 	Delete(cache *generated.CacheConfig) error
+
+	// Render writes the final kubernetes manifests of the deployment to out without deploying them
+	Render(cache *generated.CacheConfig, builtImages map[string]string, out io.Writer) error
 }
 
 // StatusResult holds the status of a deployment
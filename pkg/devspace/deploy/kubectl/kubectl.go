@@ -1,12 +1,16 @@
 package kubectl
 
 import (
+	"bytes"
+	"io"
 	"os/exec"
 	"regexp"
 	"strings"
 
 	"github.com/pkg/errors"
 	yaml "gopkg.in/yaml.v2"
+	"k8s.io/cli-runtime/pkg/kustomize"
+	"sigs.k8s.io/kustomize/pkg/fs"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
@@ -195,8 +199,28 @@ func (d *DeployConfig) Deploy(cache *generated.CacheConfig, forceDeploy bool, bu
 	return wasDeployed, nil
 }
 
+// Render writes all manifests with replaced image tags to out
+func (d *DeployConfig) Render(cache *generated.CacheConfig, builtImages map[string]string, out io.Writer) error {
+	rendered := []string{}
+	for _, manifest := range d.Manifests {
+		_, replacedManifest, err := d.getReplacedManifest(manifest, cache, builtImages)
+		if err != nil {
+			return errors.Errorf("%v\nPlease make sure `kubectl create --dry-run` does work locally with manifest `%s`", err, manifest)
+		}
+
+		rendered = append(rendered, "# Source: "+manifest+"\n"+strings.TrimSpace(replacedManifest)+"\n")
+	}
+
+	_, err := out.Write([]byte(strings.Join(rendered, "---\n")))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (d *DeployConfig) getReplacedManifest(manifest string, cache *generated.CacheConfig, builtImages map[string]string) (bool, string, error) {
-	manifestYamlBytes, err := d.dryRun(manifest)
+	manifestYamlBytes, err := d.renderManifest(manifest, cache)
 	if err != nil {
 		return false, "", err
 	}
@@ -237,6 +261,36 @@ func (d *DeployConfig) getReplacedManifest(manifest string, cache *generated.Cac
 	return shouldRedeploy, strings.Join(replaceManifests, "\n---\n"), nil
 }
 
+// renderManifest returns the resources of the manifest and replaces devspace variables in them if templating is enabled
+func (d *DeployConfig) renderManifest(manifest string, cache *generated.CacheConfig) ([]byte, error) {
+	if d.isTemplate() == false {
+		return d.dryRun(manifest)
+	}
+
+	// Kustomizations may reference files outside of the manifest path, so we template the build output instead
+	if d.DeploymentConfig.Kubectl.Kustomize != nil && *d.DeploymentConfig.Kubectl.Kustomize == true {
+		out, err := d.dryRun(manifest)
+		if err != nil {
+			return nil, err
+		}
+
+		templated, err := d.templateString(string(out), cache)
+		if err != nil {
+			return nil, errors.Wrapf(err, "template %s", manifest)
+		}
+
+		return []byte(templated), nil
+	}
+
+	templatedManifest, cleanup, err := d.templateManifest(manifest, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cleanup()
+	return d.dryRun(templatedManifest)
+}
+
 func (d *DeployConfig) getCmdArgs(method string, additionalArgs ...string) []string {
 	args := []string{}
 
@@ -257,6 +311,11 @@ func (d *DeployConfig) getCmdArgs(method string, additionalArgs ...string) []str
 }
 
 func (d *DeployConfig) dryRun(manifest string) ([]byte, error) {
+	// Kustomizations are built in-process, so the result does not depend on the installed kubectl version
+	if d.DeploymentConfig.Kubectl.Kustomize != nil && *d.DeploymentConfig.Kubectl.Kustomize == true {
+		return buildKustomization(manifest)
+	}
+
 	args := []string{"create"}
 
 	if d.Context != "" {
@@ -266,15 +325,7 @@ func (d *DeployConfig) dryRun(manifest string) ([]byte, error) {
 		args = append(args, "--namespace", d.Namespace)
 	}
 
-	args = append(args, "--dry-run", "--output", "yaml", "--validate=false")
-
-	if d.DeploymentConfig.Kubectl.Kustomize != nil && *d.DeploymentConfig.Kubectl.Kustomize == true {
-		args = append(args, "--kustomize")
-	} else {
-		args = append(args, "--filename")
-	}
-
-	args = append(args, manifest)
+	args = append(args, "--dry-run", "--output", "yaml", "--validate=false", "--filename", manifest)
 
 	// Execute command
 	output, err := exec.Command(d.CmdPath, args...).Output()
@@ -290,6 +341,17 @@ func (d *DeployConfig) dryRun(manifest string) ([]byte, error) {
 	return output, nil
 }
 
+// buildKustomization renders the kustomization in the given directory with the built-in kustomize
+func buildKustomization(path string) ([]byte, error) {
+	out := &bytes.Buffer{}
+	err := kustomize.RunKustomizeBuild(out, fs.MakeRealFS(), path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "kustomize build %s", path)
+	}
+
+	return out.Bytes(), nil
+}
+
 func replaceManifest(manifest map[interface{}]interface{}, cache *generated.CacheConfig, imagesConf map[string]*latest.ImageConfig, builtImages map[string]string) bool {
 	shouldRedeploy := false
 
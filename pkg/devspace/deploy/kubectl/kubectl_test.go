@@ -3,13 +3,17 @@ package kubectl
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 
 	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -130,15 +134,140 @@ func TestKubectlManifests(t *testing.T) {
 }
 
 func TestKubectlManifestsWithKustomize(t *testing.T) {
-	// @MoreTests
-	// 1. Create fake config & generated config
-	// 2. Write test kustomize files (see examples) into a temp folder
-	// 3. Init kubectl & create test namespace
-	// 4. Deploy files
-	// 5. Validate deployed resources
-	// 6. Delete deployed files
-	// 7. Delete test namespace
-	// 8. Delete temp folder
+	// 1. Write test kustomize files into a temp folder
+	dir, err := ioutil.TempDir("", "testKustomize")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = fsutil.WriteToFile([]byte(`resources:
+- pod.yaml
+namePrefix: dev-
+`), filepath.Join(dir, "base", "kustomization.yaml"))
+	assert.NilError(t, err)
+	err = fsutil.WriteToFile([]byte(`apiVersion: v1
+kind: Pod
+metadata:
+  name: test
+spec:
+  containers:
+  - name: test
+    image: nginx
+`), filepath.Join(dir, "base", "pod.yaml"))
+	assert.NilError(t, err)
+
+	// 2. Create fake config & generated config
+	deploymentConfig := &latest.DeploymentConfig{
+		Name: "test-deployment",
+		Kubectl: &latest.KubectlConfig{
+			Manifests: []string{filepath.Join(dir, "base", "kustomization.yaml")},
+			Kustomize: ptr.Bool(true),
+		},
+	}
+	testConfig := &latest.Config{
+		Deployments: []*latest.DeploymentConfig{
+			deploymentConfig,
+		},
+		Images: map[string]*latest.ImageConfig{
+			"default": &latest.ImageConfig{
+				Image: "nginx",
+			},
+		},
+	}
+	cache := &generated.CacheConfig{
+		Images: map[string]*generated.ImageCache{
+			"default": &generated.ImageCache{
+				ImageName: "nginx",
+				Tag:       "1.15",
+			},
+		},
+	}
+
+	// 3. Render the kustomization without a kubectl binary
+	deployConfig, err := New(testConfig, &kubectl.Client{Client: fake.NewSimpleClientset()}, deploymentConfig, log.GetInstance())
+	assert.NilError(t, err)
+
+	out := &strings.Builder{}
+	err = deployConfig.Render(cache, nil, out)
+	assert.NilError(t, err)
+
+	// 4. Validate that kustomize and the image replacement were applied
+	assert.Equal(t, strings.Contains(out.String(), "name: dev-test"), true, "Kustomization was not applied: %s", out.String())
+	assert.Equal(t, strings.Contains(out.String(), "image: nginx:1.15"), true, "Image tag was not replaced: %s", out.String())
+}
+
+func TestKubectlManifestsWithTemplate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testTemplate")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = fsutil.WriteToFile([]byte(`resources:
+- pod.yaml
+`), filepath.Join(dir, "kustomization.yaml"))
+	assert.NilError(t, err)
+	err = fsutil.WriteToFile([]byte(`apiVersion: v1
+kind: Pod
+metadata:
+  name: test
+  labels:
+    tag: ${DEVSPACE_IMAGE_TAG_MY_IMAGE}
+    owner: ${TEST_TEMPLATE_OWNER}
+    escaped: $${TEST_TEMPLATE_OWNER}
+spec:
+  containers:
+  - name: test
+    image: ${DEVSPACE_IMAGE_MY_IMAGE}
+`), filepath.Join(dir, "pod.yaml"))
+	assert.NilError(t, err)
+
+	err = os.Setenv("TEST_TEMPLATE_OWNER", "john")
+	assert.NilError(t, err)
+	defer os.Unsetenv("TEST_TEMPLATE_OWNER")
+	generated.SetTestConfig(&generated.Config{Vars: map[string]string{}})
+
+	// Variables are only replaced in parsed configs
+	testConfig, err := configutil.ParseConfig(&generated.Config{Vars: map[string]string{}}, map[interface{}]interface{}{
+		"version": latest.Version,
+		"deployments": []interface{}{
+			map[interface{}]interface{}{
+				"name": "test-deployment",
+				"kubectl": map[interface{}]interface{}{
+					"manifests": []interface{}{dir},
+					"kustomize": true,
+					"template":  true,
+				},
+			},
+		},
+		"images": map[interface{}]interface{}{
+			"my-image": map[interface{}]interface{}{
+				"image": "nginx",
+			},
+		},
+	}, &configutil.ConfigOptions{}, log.Discard)
+	assert.NilError(t, err)
+	deploymentConfig := testConfig.Deployments[0]
+	cache := &generated.CacheConfig{
+		Images: map[string]*generated.ImageCache{
+			"my-image": &generated.ImageCache{
+				ImageName: "nginx",
+				Tag:       "abcdef",
+			},
+		},
+	}
+
+	deployConfig, err := New(testConfig, &kubectl.Client{Client: fake.NewSimpleClientset()}, deploymentConfig, log.GetInstance())
+	assert.NilError(t, err)
+
+	out := &strings.Builder{}
+	err = deployConfig.Render(cache, nil, out)
+	assert.NilError(t, err)
+
+	for _, expected := range []string{"tag: abcdef", "owner: john", "escaped: ${TEST_TEMPLATE_OWNER}", "image: nginx:abcdef"} {
+		assert.Equal(t, strings.Contains(out.String(), expected), true, "Expected %s in rendered manifest: %s", expected, out.String())
+	}
 }
 
 func makeTestProject(dir string) error {
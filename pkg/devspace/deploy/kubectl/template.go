@@ -0,0 +1,119 @@
+package kubectl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
+	"github.com/pkg/errors"
+)
+
+var imageVarNameRegex = regexp.MustCompile("[^A-Z0-9_]")
+
+// isTemplate returns if the manifests should be templated with devspace variables before they are applied
+func (d *DeployConfig) isTemplate() bool {
+	return d.DeploymentConfig.Kubectl.Template != nil && *d.DeploymentConfig.Kubectl.Template == true
+}
+
+// templateManifest replaces all variables in the given manifest file or directory and
+// writes the result into a temporary directory. The returned function removes the temporary directory
+func (d *DeployConfig) templateManifest(manifest string, cache *generated.CacheConfig) (string, func(), error) {
+	stat, err := os.Stat(manifest)
+	if err != nil {
+		return "", nil, err
+	}
+
+	files := []string{manifest}
+	if stat.IsDir() {
+		files, err = getManifestFiles(manifest)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	tempDir, err := ioutil.TempDir("", "devspace-manifests")
+	if err != nil {
+		return "", nil, errors.Wrap(err, "create temp dir")
+	}
+
+	cleanup := func() { os.RemoveAll(tempDir) }
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			cleanup()
+			return "", nil, err
+		}
+
+		templated, err := d.templateString(string(content), cache)
+		if err != nil {
+			cleanup()
+			return "", nil, errors.Wrapf(err, "template %s", file)
+		}
+
+		err = fsutil.WriteToFile([]byte(templated), filepath.Join(tempDir, filepath.Base(file)))
+		if err != nil {
+			cleanup()
+			return "", nil, err
+		}
+	}
+
+	if stat.IsDir() {
+		return tempDir, cleanup, nil
+	}
+
+	return filepath.Join(tempDir, filepath.Base(manifest)), cleanup, nil
+}
+
+// templateString replaces all variables and image variables in the given string
+func (d *DeployConfig) templateString(content string, cache *generated.CacheConfig) (string, error) {
+	return configutil.ReplaceVars(d.config, content, getImageVars(d.config.Images, cache), d.Log)
+}
+
+// getImageVars returns the variables DEVSPACE_IMAGE_[KEY] and DEVSPACE_IMAGE_TAG_[KEY] for all images with a generated tag
+func getImageVars(imagesConf map[string]*latest.ImageConfig, cache *generated.CacheConfig) map[string]string {
+	vars := map[string]string{}
+	if cache == nil {
+		return vars
+	}
+
+	for key, imageConf := range imagesConf {
+		imageCache, ok := cache.Images[key]
+		if !ok || imageCache.Tag == "" {
+			continue
+		}
+
+		name := imageVarNameRegex.ReplaceAllString(strings.ToUpper(key), "_")
+		vars["DEVSPACE_IMAGE_"+name] = imageConf.Image + ":" + imageCache.Tag
+		vars["DEVSPACE_IMAGE_TAG_"+name] = imageCache.Tag
+	}
+
+	return vars
+}
+
+// getManifestFiles returns the files in a directory that kubectl would read as manifests
+func getManifestFiles(dir string) ([]string, error) {
+	fileInfos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for _, fileInfo := range fileInfos {
+		if fileInfo.IsDir() {
+			continue
+		}
+
+		switch filepath.Ext(fileInfo.Name()) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, filepath.Join(dir, fileInfo.Name()))
+		}
+	}
+
+	return files, nil
+}
@@ -0,0 +1,60 @@
+package deploy
+
+import (
+	"io"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/diff"
+	kubectlpkg "github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+)
+
+// Diff renders all deployments (or only the specified ones) and compares the resulting objects with the live objects in the cluster.
+// The differences are written to out and the number of objects that would be created or changed is returned
+func Diff(config *latest.Config, cache *generated.CacheConfig, client *kubectlpkg.Client, deployments []string, out io.Writer, log log.Logger) (int, error) {
+	if len(config.Deployments) == 0 {
+		return 0, nil
+	}
+
+	differ, err := diff.NewDiffer(client)
+	if err != nil {
+		return 0, err
+	}
+
+	rendered, err := Render(config, cache, client, deployments, log)
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, deployment := range rendered {
+		results, err := differ.Diff(deployment.Manifests, deployment.Namespace)
+		if err != nil {
+			return 0, errors.Errorf("Error comparing %s: %v", deployment.Name, err)
+		}
+
+		deploymentChanged := 0
+		for _, result := range results {
+			if result.Status != diff.StatusUnchanged {
+				deploymentChanged++
+			}
+		}
+		if deploymentChanged == 0 {
+			log.Infof("Deployment %s is up to date", deployment.Name)
+			continue
+		}
+
+		log.Infof("Deployment %s would change %d object(s):", ansi.Color(deployment.Name, "white+b"), deploymentChanged)
+		err = diff.Print(results, out)
+		if err != nil {
+			return 0, err
+		}
+
+		changed += deploymentChanged
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,55 @@
+package deploy
+
+import (
+	"bytes"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	kubectlpkg "github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// RenderedDeployment holds the final kubernetes manifests of a single deployment
+type RenderedDeployment struct {
+	Name      string
+	Namespace string
+	Manifests string
+}
+
+// Render renders all deployments (or only the specified ones) without deploying them. Image tags are taken from the cache
+func Render(config *latest.Config, cache *generated.CacheConfig, client *kubectlpkg.Client, deployments []string, log log.Logger) ([]*RenderedDeployment, error) {
+	rendered := []*RenderedDeployment{}
+
+	for _, deployConfig := range config.Deployments {
+		if isSelected(deployConfig.Name, deployments) == false {
+			continue
+		}
+
+		deployClient, err := newDeployClient(config, client, deployConfig, log)
+		if err != nil {
+			return nil, err
+		}
+
+		log.StartWait("Rendering deployment " + deployConfig.Name)
+		manifests := &bytes.Buffer{}
+		err = deployClient.Render(cache, nil, manifests)
+		log.StopWait()
+		if err != nil {
+			return nil, errors.Errorf("Error rendering %s: %v", deployConfig.Name, err)
+		}
+
+		namespace := client.Namespace
+		if deployConfig.Namespace != "" {
+			namespace = deployConfig.Namespace
+		}
+
+		rendered = append(rendered, &RenderedDeployment{
+			Name:      deployConfig.Name,
+			Namespace: namespace,
+			Manifests: manifests.String(),
+		})
+	}
+
+	return rendered, nil
+}
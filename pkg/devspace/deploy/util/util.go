@@ -155,3 +155,39 @@ func PurgeDeployments(config *latest.Config, cache *generated.CacheConfig, clien
 		}
 	}
 }
+
+// newDeployClient creates the deploy client for the deployment method of the given deployment config
+func newDeployClient(config *latest.Config, client *kubectlpkg.Client, deployConfig *latest.DeploymentConfig, log log.Logger) (deploy.Interface, error) {
+	if deployConfig.Kubectl != nil {
+		deployClient, err := kubectl.New(config, client, deployConfig, log)
+		if err != nil {
+			return nil, errors.Errorf("Error creating kubectl deploy config for %s: %v", deployConfig.Name, err)
+		}
+
+		return deployClient, nil
+	} else if deployConfig.Helm != nil {
+		deployClient, err := helm.New(config, client, deployConfig, log)
+		if err != nil {
+			return nil, errors.Errorf("Error creating helm deploy config for %s: %v", deployConfig.Name, err)
+		}
+
+		return deployClient, nil
+	}
+
+	return nil, errors.Errorf("Deployment %s has no deployment method", deployConfig.Name)
+}
+
+// isSelected checks if the deployment should be used, an empty selection means all deployments
+func isSelected(name string, deployments []string) bool {
+	if len(deployments) == 0 {
+		return true
+	}
+
+	for _, deployment := range deployments {
+		if deployment == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -29,6 +29,11 @@ func (client *Client) GetAuthConfig(registryURL string, checkCredentialsStore bo
 	return getDefaultAuthConfig(checkCredentialsStore, serverAddress, isDefaultRegistry)
 }
 
+// GetAuthConfigFromStore returns the AuthConfig for a registry from the docker config and credential helpers without contacting the docker daemon
+func GetAuthConfigFromStore(registryURL string) (*types.AuthConfig, error) {
+	return getDefaultAuthConfig(true, registryURL, false)
+}
+
 // Login logs the user into docker
 func (client *Client) Login(registryURL, user, password string, checkCredentialsStore, saveAuthConfig, relogin bool) (*types.AuthConfig, error) {
 	ctx := context.Background()
@@ -30,6 +30,7 @@ import (
 // Interface is the client interface for helm
 type Interface interface {
 	InstallChart(releaseName string, releaseNamespace string, values *map[interface{}]interface{}, helmConfig *latest.HelmConfig) (*hapi_release5.Release, error)
+	Template(releaseName string, releaseNamespace string, values *map[interface{}]interface{}, helmConfig *latest.HelmConfig) (string, error)
 	DeleteRelease(releaseName string, purge bool) (*rls.UninstallReleaseResponse, error)
 	ListReleases() (*rls.ListReleasesResponse, error)
 }
@@ -2,6 +2,7 @@ package helm
 
 import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	yaml "gopkg.in/yaml.v2"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/helm/pkg/helm"
 	k8shelm "k8s.io/helm/pkg/helm"
@@ -73,3 +74,13 @@ func (f *FakeClient) InstallChart(releaseName string, releaseNamespace string, v
 
 	return installResponse.GetRelease(), nil
 }
+
+// Template implements interface
+func (f *FakeClient) Template(releaseName string, releaseNamespace string, values *map[interface{}]interface{}, helmConfig *latest.HelmConfig) (string, error) {
+	out, err := yaml.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
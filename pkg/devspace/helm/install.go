@@ -47,12 +47,8 @@ func checkDependencies(ch *chart.Chart, reqs *helmchartutil.Requirements) error
 	return nil
 }
 
-// InstallChartByPath installs the given chartpath und the releasename in the releasenamespace
-func (client *Client) InstallChartByPath(releaseName, releaseNamespace, chartPath string, values *map[interface{}]interface{}, helmConfig *latest.HelmConfig) (*hapi_release5.Release, error) {
-	if releaseNamespace == "" {
-		releaseNamespace = client.kubectl.Namespace
-	}
-
+// loadChart loads the chart from the given path and updates its dependencies if necessary
+func (client *Client) loadChart(chartPath string) (*chart.Chart, error) {
 	chart, err := helmchartutil.Load(chartPath)
 	if err != nil {
 		return nil, err
@@ -83,6 +79,20 @@ func (client *Client) InstallChartByPath(releaseName, releaseNamespace, chartPat
 		return nil, errors.Errorf("cannot load requirements: %v", err)
 	}
 
+	return chart, nil
+}
+
+// InstallChartByPath installs the given chartpath und the releasename in the releasenamespace
+func (client *Client) InstallChartByPath(releaseName, releaseNamespace, chartPath string, values *map[interface{}]interface{}, helmConfig *latest.HelmConfig) (*hapi_release5.Release, error) {
+	if releaseNamespace == "" {
+		releaseNamespace = client.kubectl.Namespace
+	}
+
+	chart, err := client.loadChart(chartPath)
+	if err != nil {
+		return nil, err
+	}
+
 	releaseExists := ReleaseExists(client.helm, releaseName)
 	overwriteValues := []byte("")
 
@@ -191,8 +201,7 @@ func (client *Client) analyzeError(srcErr error, releaseNamespace string) error
 
 // InstallChart installs the given chart by name under the releasename in the releasenamespace
 func (client *Client) InstallChart(releaseName string, releaseNamespace string, values *map[interface{}]interface{}, helmConfig *latest.HelmConfig) (*hapi_release5.Release, error) {
-	chart := helmConfig.Chart
-	chartPath, err := locateChartPath(client.Settings, chart.RepoURL, chart.Username, chart.Password, chart.Name, chart.Version, false, "", "", "", "")
+	chartPath, err := client.locateChart(helmConfig.Chart)
 	if err != nil {
 		return nil, errors.Wrap(err, "locate chart path")
 	}
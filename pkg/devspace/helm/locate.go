@@ -1,6 +1,7 @@
 package helm
 
 import (
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"k8s.io/helm/pkg/downloader"
 	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/helm/environment"
@@ -13,6 +14,27 @@ import (
 	"strings"
 )
 
+// locateChart returns the local path of the chart in the chart config and downloads it if necessary
+func (client *Client) locateChart(chart *latest.ChartConfig) (string, error) {
+	return locateChartPath(client.Settings, chart.RepoURL, chart.Username, chart.Password, chart.Name, chart.Version, false, "", chart.CertFile, chart.KeyFile, chart.CAFile)
+}
+
+// getTLSGetters returns the given getters with an additional http getter in front that uses the given client certificates
+func getTLSGetters(getters getter.Providers, certFile, keyFile, caFile string) getter.Providers {
+	if certFile == "" && keyFile == "" && caFile == "" {
+		return getters
+	}
+
+	tlsGetter := getter.Provider{
+		Schemes: []string{"http", "https"},
+		New: func(URL, _, _, _ string) (getter.Getter, error) {
+			return getter.NewHTTPGetter(URL, certFile, keyFile, caFile)
+		},
+	}
+
+	return append(getter.Providers{tlsGetter}, getters...)
+}
+
 // Code is taken from https://github.com/helm/helm/blob/master/cmd/helm/install.go
 // locateChartPath looks for a chart directory in known places, and returns either the full path or an error.
 //
@@ -48,16 +70,21 @@ func locateChartPath(settings *environment.EnvSettings, repoURL, username, passw
 		return name, errors.Errorf("path %q not found", name)
 	}
 
+	if strings.HasPrefix(name, OCIPrefix) {
+		return pullOCIChart(name, version, username, password, certFile, keyFile, caFile)
+	}
+
 	crepo := filepath.Join(settings.Home.Repository(), name)
 	if _, err := os.Stat(crepo); err == nil {
 		return filepath.Abs(crepo)
 	}
 
+	getters := getTLSGetters(getter.All(*settings), certFile, keyFile, caFile)
 	dl := downloader.ChartDownloader{
 		HelmHome: settings.Home,
 		Out:      os.Stdout,
 		Keyring:  keyring,
-		Getters:  getter.All(*settings),
+		Getters:  getters,
 		Username: username,
 		Password: password,
 	}
@@ -66,7 +93,7 @@ func locateChartPath(settings *environment.EnvSettings, repoURL, username, passw
 	}
 	if repoURL != "" {
 		chartURL, err := repo.FindChartInAuthRepoURL(repoURL, username, password, name, version,
-			certFile, keyFile, caFile, getter.All(*settings))
+			certFile, keyFile, caFile, getters)
 		if err != nil {
 			return "", err
 		}
@@ -0,0 +1,406 @@
+package helm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
+	"github.com/devspace-cloud/devspace/pkg/devspace/docker"
+	"github.com/docker/distribution/registry/client/auth/challenge"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	"k8s.io/helm/pkg/tlsutil"
+)
+
+// OCIPrefix is the prefix of chart names that reference a chart in an OCI registry
+const OCIPrefix = "oci://"
+
+// ChartCacheFolder is the folder in the devspace home folder where pulled charts are stored
+const ChartCacheFolder = "charts"
+
+const (
+	ociManifestMediaType   = "application/vnd.oci.image.manifest.v1+json"
+	helmChartMediaType     = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+	helmChartMediaTypeOld  = "application/tar+gzip"
+	digestAlgorithmSHA256  = "sha256:"
+	defaultOCIChartVersion = "latest"
+)
+
+// ociReference is a parsed oci://registry/repository:tag reference
+type ociReference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+type ociManifest struct {
+	Layers []ociDescriptor `json:"layers"`
+}
+
+// ociClient pulls charts from an OCI registry
+type ociClient struct {
+	client   *http.Client
+	ref      *ociReference
+	username string
+	password string
+	token    string
+
+	identityToken string
+}
+
+// parseOCIReference parses a chart name in the form oci://registry/repository[:tag|@digest]
+func parseOCIReference(name, version string) (*ociReference, error) {
+	trimmed := strings.TrimPrefix(name, OCIPrefix)
+	splitted := strings.SplitN(trimmed, "/", 2)
+	if len(splitted) != 2 || splitted[0] == "" || splitted[1] == "" {
+		return nil, errors.Errorf("invalid oci chart reference %s: expected oci://registry/repository[:version]", name)
+	}
+
+	ref := &ociReference{
+		Registry:   splitted[0],
+		Repository: splitted[1],
+		Tag:        strings.TrimSpace(version),
+	}
+
+	if idx := strings.Index(ref.Repository, "@"); idx != -1 {
+		ref.Digest = ref.Repository[idx+1:]
+		ref.Repository = ref.Repository[:idx]
+		if strings.HasPrefix(ref.Digest, digestAlgorithmSHA256) == false {
+			return nil, errors.Errorf("invalid oci chart reference %s: only sha256 digests are supported", name)
+		}
+	} else if idx := strings.LastIndex(ref.Repository, ":"); idx != -1 {
+		if ref.Tag != "" && ref.Tag != ref.Repository[idx+1:] {
+			return nil, errors.Errorf("chart version %s does not match the tag in %s", ref.Tag, name)
+		}
+
+		ref.Tag = ref.Repository[idx+1:]
+		ref.Repository = ref.Repository[:idx]
+	}
+
+	if ref.Tag == "" {
+		ref.Tag = defaultOCIChartVersion
+	}
+
+	return ref, nil
+}
+
+// pullOCIChart downloads the chart from an OCI registry into the local chart cache and returns the path to the chart archive.
+// Charts are cached by their digest, so a chart is only downloaded again if the tag points to new content
+func pullOCIChart(name, version, username, password, certFile, keyFile, caFile string) (string, error) {
+	cacheDir, err := getChartCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	chartPath, _, err := pullOCILayer(name, version, cacheDir, username, password, certFile, keyFile, caFile, isChartLayer)
+	if err != nil {
+		return "", errors.Wrapf(err, "pull chart %s", name)
+	}
+
+	return chartPath, nil
+}
+
+// PullOCIArchive downloads the first tar+gzip layer of an OCI artifact (e.g. a packaged helm chart) into cacheDir and returns
+// the path to the archive and its digest. Archives are cached by their digest, so an artifact is only downloaded again if
+// the tag points to new content
+func PullOCIArchive(reference, cacheDir string) (string, string, error) {
+	if strings.HasPrefix(reference, OCIPrefix) == false {
+		reference = OCIPrefix + reference
+	}
+
+	return pullOCILayer(reference, "", cacheDir, "", "", "", "", "", isArchiveLayer)
+}
+
+func pullOCILayer(name, version, cacheDir, username, password, certFile, keyFile, caFile string, isLayer func(mediaType string) bool) (string, string, error) {
+	ref, err := parseOCIReference(name, version)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Artifacts referenced by digest do not need to be resolved
+	if ref.Digest != "" {
+		cachedPath := getCachedLayerPath(cacheDir, ref.Digest)
+		if _, err := os.Stat(cachedPath); err == nil {
+			return cachedPath, ref.Digest, nil
+		}
+	}
+
+	client, err := newOCIClient(ref, username, password, certFile, keyFile, caFile)
+	if err != nil {
+		return "", "", err
+	}
+
+	layer, err := client.getLayer(isLayer)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "resolve %s", name)
+	}
+
+	cachedPath := getCachedLayerPath(cacheDir, layer.Digest)
+	if _, err := os.Stat(cachedPath); err == nil {
+		return cachedPath, layer.Digest, nil
+	}
+
+	err = client.downloadBlob(layer, cachedPath)
+	if err != nil {
+		return "", "", err
+	}
+
+	return cachedPath, layer.Digest, nil
+}
+
+func isChartLayer(mediaType string) bool {
+	return mediaType == helmChartMediaType || mediaType == helmChartMediaTypeOld
+}
+
+func isArchiveLayer(mediaType string) bool {
+	return strings.HasSuffix(mediaType, "tar+gzip") || strings.HasSuffix(mediaType, "tar.gzip")
+}
+
+func getChartCacheDir() (string, error) {
+	homeDir, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homeDir, constants.DefaultHomeDevSpaceFolder, ChartCacheFolder), nil
+}
+
+func getCachedLayerPath(cacheDir, digest string) string {
+	return filepath.Join(cacheDir, "sha256", strings.TrimPrefix(digest, digestAlgorithmSHA256)+".tgz")
+}
+
+func newOCIClient(ref *ociReference, username, password, certFile, keyFile, caFile string) (*ociClient, error) {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+	}
+	if (certFile != "" && keyFile != "") || caFile != "" {
+		tlsConfig, err := tlsutil.NewClientTLS(certFile, keyFile, caFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "create tls config")
+		}
+
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	client := &ociClient{
+		client:   &http.Client{Transport: transport},
+		ref:      ref,
+		username: username,
+		password: password,
+	}
+
+	// Use the docker credentials if no credentials are specified in the chart config
+	if username == "" && password == "" {
+		authConfig, err := docker.GetAuthConfigFromStore(ref.Registry)
+		if err == nil && authConfig != nil {
+			client.username = authConfig.Username
+			client.password = authConfig.Password
+			client.identityToken = authConfig.IdentityToken
+		}
+	}
+
+	return client, nil
+}
+
+func (c *ociClient) getLayer(isLayer func(mediaType string) bool) (*ociDescriptor, error) {
+	reference := c.ref.Tag
+	if c.ref.Digest != "" {
+		reference = c.ref.Digest
+	}
+
+	resp, err := c.get(c.registryURL("manifests", reference), ociManifestMediaType)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	manifest := &ociManifest{}
+	err = json.NewDecoder(resp.Body).Decode(manifest)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode manifest")
+	}
+
+	for _, layer := range manifest.Layers {
+		if isLayer(layer.MediaType) {
+			if strings.HasPrefix(layer.Digest, digestAlgorithmSHA256) == false {
+				return nil, errors.Errorf("unsupported digest %s", layer.Digest)
+			}
+
+			return &layer, nil
+		}
+	}
+
+	return nil, errors.Errorf("%s/%s:%s contains no supported layer", c.ref.Registry, c.ref.Repository, c.ref.Tag)
+}
+
+func (c *ociClient) downloadBlob(layer *ociDescriptor, target string) error {
+	resp, err := c.get(c.registryURL("blobs", layer.Digest), "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	err = os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return err
+	}
+
+	tempFile, err := ioutil.TempFile(filepath.Dir(target), "download")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	hasher := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tempFile, hasher), resp.Body)
+	tempFile.Close()
+	if err != nil {
+		return err
+	}
+
+	digest := digestAlgorithmSHA256 + hex.EncodeToString(hasher.Sum(nil))
+	if digest != layer.Digest {
+		return errors.Errorf("digest mismatch: expected %s, got %s", layer.Digest, digest)
+	}
+
+	return os.Rename(tempFile.Name(), target)
+}
+
+func (c *ociClient) registryURL(kind, reference string) string {
+	return "https://" + c.ref.Registry + "/v2/" + c.ref.Repository + "/" + kind + "/" + reference
+}
+
+// get sends a GET request and authenticates against the registry if the registry requests it
+func (c *ociClient) get(requestURL, accept string) (*http.Response, error) {
+	resp, err := c.do(requestURL, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized && c.token == "" {
+		challenges := challenge.ResponseChallenges(resp)
+		resp.Body.Close()
+
+		err = c.authenticate(challenges)
+		if err != nil {
+			return nil, errors.Wrap(err, "authenticate")
+		}
+
+		resp, err = c.do(requestURL, accept)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		return nil, errors.Errorf("GET %s: %s %s", requestURL, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return resp, nil
+}
+
+func (c *ociClient) do(requestURL, accept string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	} else if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	return c.client.Do(req)
+}
+
+// authenticate retrieves a bearer token for the repository from the token server of the registry
+func (c *ociClient) authenticate(challenges []challenge.Challenge) error {
+	for _, ch := range challenges {
+		if strings.ToLower(ch.Scheme) != "bearer" {
+			continue
+		}
+
+		realm := ch.Parameters["realm"]
+		if realm == "" {
+			return errors.New("bearer challenge without realm")
+		}
+
+		params := url.Values{}
+		params.Set("scope", "repository:"+c.ref.Repository+":pull")
+		if service := ch.Parameters["service"]; service != "" {
+			params.Set("service", service)
+		}
+
+		var (
+			resp *http.Response
+			err  error
+		)
+		if c.identityToken != "" {
+			params.Set("grant_type", "refresh_token")
+			params.Set("refresh_token", c.identityToken)
+			params.Set("client_id", "devspace")
+			resp, err = c.client.PostForm(realm, params)
+		} else {
+			req, reqErr := http.NewRequest("GET", realm+"?"+params.Encode(), nil)
+			if reqErr != nil {
+				return reqErr
+			}
+			if c.username != "" || c.password != "" {
+				req.SetBasicAuth(c.username, c.password)
+			}
+
+			resp, err = c.client.Do(req)
+		}
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return errors.Errorf("token server returned %s", resp.Status)
+		}
+
+		tokenResponse := struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}{}
+		err = json.NewDecoder(resp.Body).Decode(&tokenResponse)
+		if err != nil {
+			return errors.Wrap(err, "decode token")
+		}
+
+		c.token = tokenResponse.Token
+		if c.token == "" {
+			c.token = tokenResponse.AccessToken
+		}
+		if c.token == "" {
+			return errors.New("token server returned no token")
+		}
+
+		return nil
+	}
+
+	return errors.New("unauthorized: please login via `docker login` or specify username and password in the chart config")
+}
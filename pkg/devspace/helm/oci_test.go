@@ -0,0 +1,151 @@
+package helm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
+	homedir "github.com/mitchellh/go-homedir"
+
+	"gotest.tools/assert"
+)
+
+type parseOCIReferenceTestCase struct {
+	name string
+
+	chartName string
+	version   string
+
+	expectedErr string
+	expected    *ociReference
+}
+
+func TestParseOCIReference(t *testing.T) {
+	testCases := []parseOCIReferenceTestCase{
+		parseOCIReferenceTestCase{
+			name:      "Tag in name",
+			chartName: "oci://registry.example.com/charts/app:1.0.0",
+			expected:  &ociReference{Registry: "registry.example.com", Repository: "charts/app", Tag: "1.0.0"},
+		},
+		parseOCIReferenceTestCase{
+			name:      "Tag from version",
+			chartName: "oci://localhost:5000/app",
+			version:   "0.1.0",
+			expected:  &ociReference{Registry: "localhost:5000", Repository: "app", Tag: "0.1.0"},
+		},
+		parseOCIReferenceTestCase{
+			name:      "Default tag",
+			chartName: "oci://localhost:5000/app",
+			expected:  &ociReference{Registry: "localhost:5000", Repository: "app", Tag: "latest"},
+		},
+		parseOCIReferenceTestCase{
+			name:      "Digest",
+			chartName: "oci://registry.example.com/app@sha256:abc",
+			expected:  &ociReference{Registry: "registry.example.com", Repository: "app", Tag: "latest", Digest: "sha256:abc"},
+		},
+		parseOCIReferenceTestCase{
+			name:        "Version mismatch",
+			chartName:   "oci://registry.example.com/app:1.0.0",
+			version:     "2.0.0",
+			expectedErr: "chart version 2.0.0 does not match the tag in oci://registry.example.com/app:1.0.0",
+		},
+		parseOCIReferenceTestCase{
+			name:        "Missing repository",
+			chartName:   "oci://registry.example.com",
+			expectedErr: "invalid oci chart reference oci://registry.example.com: expected oci://registry/repository[:version]",
+		},
+	}
+
+	for _, testCase := range testCases {
+		ref, err := parseOCIReference(testCase.chartName, testCase.version)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+			assert.DeepEqual(t, ref, testCase.expected)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+		}
+	}
+}
+
+func TestPullOCIChart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testOCI")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Use the temp dir as home dir for the chart cache
+	homedir.DisableCache = true
+	defer func() { homedir.DisableCache = false }()
+	homeBackup := os.Getenv("HOME")
+	defer os.Setenv("HOME", homeBackup)
+	err = os.Setenv("HOME", dir)
+	assert.NilError(t, err)
+
+	chartContent := []byte("chart-archive")
+	hash := sha256.Sum256(chartContent)
+	digest := "sha256:" + hex.EncodeToString(hash[:])
+
+	blobRequests := 0
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			user, password, _ := r.BasicAuth()
+			if user != "user" || password != "pass" || r.URL.Query().Get("scope") != "repository:charts/app:pull" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			json.NewEncoder(w).Encode(map[string]string{"token": "secret-token"})
+		case r.Header.Get("Authorization") != "Bearer secret-token":
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+server.URL+`/token",service="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/charts/app/manifests/1.0.0":
+			json.NewEncoder(w).Encode(ociManifest{
+				Layers: []ociDescriptor{
+					ociDescriptor{MediaType: "application/vnd.cncf.helm.config.v1+json", Digest: "sha256:config"},
+					ociDescriptor{MediaType: helmChartMediaType, Digest: digest},
+				},
+			})
+		case r.URL.Path == "/v2/charts/app/blobs/"+digest:
+			blobRequests++
+			w.Write(chartContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Trust the test server via caFile
+	caFile := filepath.Join(dir, "ca.pem")
+	err = fsutil.WriteToFile(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), caFile)
+	assert.NilError(t, err)
+
+	chartName := "oci://" + strings.TrimPrefix(server.URL, "https://") + "/charts/app"
+	for i := 0; i < 2; i++ {
+		chartPath, err := pullOCIChart(chartName, "1.0.0", "user", "pass", "", "", caFile)
+		assert.NilError(t, err)
+		assert.Equal(t, chartPath, filepath.Join(dir, ".devspace", "charts", "sha256", hex.EncodeToString(hash[:])+".tgz"))
+
+		content, err := ioutil.ReadFile(chartPath)
+		assert.NilError(t, err)
+		assert.Equal(t, string(content), string(chartContent))
+	}
+
+	// The second pull should be served from the cache
+	assert.Equal(t, blobRequests, 1)
+
+	// Without the ca file the server certificate is not trusted
+	_, err = pullOCIChart(chartName, "1.0.0", "user", "pass", "", "", "")
+	assert.Equal(t, err != nil, true)
+}
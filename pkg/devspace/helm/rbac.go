@@ -1,14 +1,13 @@
 package helm
 
 import (
-	"regexp"
-
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
 
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	k8sv1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -21,8 +20,6 @@ const TillerRoleName = "devspace-tiller"
 // TillerRoleManagerName is the name of the role with minimal rights to allow tiller to manage itself
 const TillerRoleManagerName = "tiller-config-manager"
 
-var alreadyExistsRegexp = regexp.MustCompile(".* already exists$")
-
 func createTillerRBAC(config *latest.Config, client *kubectl.Client, tillerNamespace string, log log.Logger) error {
 	// Create service account
 	err := createTillerServiceAccount(client, tillerNamespace)
@@ -62,7 +59,7 @@ func createTillerRBAC(config *latest.Config, client *kubectl.Client, tillerNames
 						Name: appNamespace,
 					},
 				})
-				if err != nil {
+				if err != nil && kerrors.IsAlreadyExists(err) == false {
 					return err
 				}
 			}
@@ -84,8 +81,11 @@ func createTillerServiceAccount(client *kubectl.Client, tillerNamespace string)
 			Namespace: tillerNamespace,
 		},
 	})
+	if err != nil && kerrors.IsAlreadyExists(err) == false {
+		return err
+	}
 
-	return err
+	return nil
 }
 
 func addDeployAccessToTiller(client *kubectl.Client, tillerNamespace, namespace string) error {
@@ -106,7 +106,7 @@ func addDeployAccessToTiller(client *kubectl.Client, tillerNamespace, namespace
 			},
 		},
 	})
-	if err != nil && alreadyExistsRegexp.Match([]byte(err.Error())) == false {
+	if err != nil && kerrors.IsAlreadyExists(err) == false {
 		return err
 	}
 
@@ -128,7 +128,7 @@ func addDeployAccessToTiller(client *kubectl.Client, tillerNamespace, namespace
 			Name:     TillerRoleName,
 		},
 	})
-	if err != nil && alreadyExistsRegexp.Match([]byte(err.Error())) == false {
+	if err != nil && kerrors.IsAlreadyExists(err) == false {
 		return err
 	}
 
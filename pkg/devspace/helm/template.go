@@ -0,0 +1,82 @@
+package helm
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+
+	yaml "gopkg.in/yaml.v2"
+	helmchartutil "k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/renderutil"
+)
+
+// NewTemplateClient creates a new helm client that is only able to render charts locally and does not need tiller
+func NewTemplateClient(config *latest.Config, kubeClient *kubectl.Client, log log.Logger) (*Client, error) {
+	return create(config, kubeClient.Namespace, nil, kubeClient, log)
+}
+
+// Template renders the given chart locally with the given values and returns the resulting kubernetes manifests
+func (client *Client) Template(releaseName, releaseNamespace string, values *map[interface{}]interface{}, helmConfig *latest.HelmConfig) (string, error) {
+	if releaseNamespace == "" {
+		releaseNamespace = client.kubectl.Namespace
+	}
+
+	chartPath, err := client.locateChart(helmConfig.Chart)
+	if err != nil {
+		return "", errors.Wrap(err, "locate chart path")
+	}
+
+	loadedChart, err := client.loadChart(chartPath)
+	if err != nil {
+		return "", err
+	}
+
+	overwriteValues := []byte("{}")
+	if values != nil {
+		overwriteValues, err = yaml.Marshal(values)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	templates, err := renderutil.Render(loadedChart, &chart.Config{Raw: string(overwriteValues)}, renderutil.Options{
+		ReleaseOptions: helmchartutil.ReleaseOptions{
+			Name:      releaseName,
+			Namespace: releaseNamespace,
+			IsInstall: true,
+		},
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "render chart")
+	}
+
+	return joinTemplates(templates), nil
+}
+
+// joinTemplates concatenates the rendered templates in a stable order and skips notes, partials and empty files
+func joinTemplates(templates map[string]string) string {
+	names := make([]string, 0, len(templates))
+	for name, content := range templates {
+		base := filepath.Base(name)
+		if strings.HasPrefix(base, "_") || strings.HasSuffix(base, "NOTES.txt") || strings.TrimSpace(content) == "" {
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	manifests := make([]string, 0, len(names))
+	for _, name := range names {
+		manifests = append(manifests, "# Source: "+name+"\n"+strings.TrimSpace(templates[name])+"\n")
+	}
+
+	return strings.Join(manifests, "---\n")
+}
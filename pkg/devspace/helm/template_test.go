@@ -0,0 +1,20 @@
+package helm
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestJoinTemplates(t *testing.T) {
+	templates := map[string]string{
+		"chart/templates/service.yaml":    "kind: Service\n",
+		"chart/templates/deployment.yaml": "\nkind: Deployment\n",
+		"chart/templates/_helpers.tpl":    "{{ define \"name\" }}{{ end }}",
+		"chart/templates/NOTES.txt":       "Thank you for installing",
+		"chart/templates/empty.yaml":      "  \n",
+	}
+
+	expected := "# Source: chart/templates/deployment.yaml\nkind: Deployment\n---\n# Source: chart/templates/service.yaml\nkind: Service\n"
+	assert.Equal(t, joinTemplates(templates), expected)
+}
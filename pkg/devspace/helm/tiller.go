@@ -8,6 +8,7 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	k8sv1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	helminstaller "k8s.io/helm/cmd/helm/installer"
 )
@@ -40,7 +41,7 @@ func ensureTiller(config *latest.Config, client *kubectl.Client, tillerNamespace
 				Name: tillerNamespace,
 			},
 		})
-		if err != nil {
+		if err != nil && kerrors.IsAlreadyExists(err) == false {
 			return err
 		}
 	}
@@ -90,9 +91,9 @@ func createTiller(config *latest.Config, client *kubectl.Client, tillerNamespace
 		}
 	}
 
-	// Create the deployment
+	// Create the deployment, it might have been created concurrently by another deployment
 	err = helminstaller.Install(client.Client, tillerOptions)
-	if err != nil {
+	if err != nil && kerrors.IsAlreadyExists(err) == false {
 		return err
 	}
 
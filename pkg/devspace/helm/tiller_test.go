@@ -117,6 +117,31 @@ func TestTillerCreate(t *testing.T) {
 	}
 }
 
+func TestTillerCreateConcurrently(t *testing.T) {
+	config := createFakeConfig()
+
+	// Create the fake client.
+	client := &kubectl.Client{
+		Client: fake.NewSimpleClientset(),
+	}
+
+	tillerOptions := getTillerOptions(configutil.TestNamespace)
+
+	// A second deployment that creates tiller at the same time runs into already existing resources
+	err := createTiller(config, client, configutil.TestNamespace, tillerOptions, log.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = createTillerRBAC(config, client, configutil.TestNamespace, log.Discard)
+	if err != nil {
+		t.Fatalf("Error creating tiller rbac twice: %v", err)
+	}
+	err = createTiller(config, client, configutil.TestNamespace, tillerOptions, log.Discard)
+	if err != nil {
+		t.Fatalf("Error creating tiller twice: %v", err)
+	}
+}
+
 func TestTillerDelete(t *testing.T) {
 	config := createFakeConfig()
 
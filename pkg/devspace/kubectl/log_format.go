@@ -0,0 +1,183 @@
+package kubectl
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+)
+
+// Keys that are printed in front of the other fields of json log lines
+var (
+	jsonTimeKeys    = []string{"time", "ts", "timestamp", "@timestamp"}
+	jsonLevelKeys   = []string{"level", "lvl", "severity"}
+	jsonMessageKeys = []string{"msg", "message"}
+)
+
+// levelColors holds the colors of the log levels of json log lines
+var levelColors = map[string]string{
+	"trace":    "white",
+	"debug":    "white",
+	"info":     "green+b",
+	"warn":     "yellow+b",
+	"warning":  "yellow+b",
+	"error":    "red+b",
+	"fatal":    "red+b",
+	"panic":    "red+b",
+	"critical": "red+b",
+}
+
+// LogFormatter filters log lines and formats json log lines
+type LogFormatter struct {
+	Include []*regexp.Regexp
+	Exclude []*regexp.Regexp
+
+	// FormatJSON prints json log lines as `time LEVEL message key=value ...`
+	FormatJSON bool
+}
+
+// NewLogFormatter compiles the include and exclude patterns and creates a new log formatter
+func NewLogFormatter(include, exclude []string, formatJSON bool) (*LogFormatter, error) {
+	formatter := &LogFormatter{
+		FormatJSON: formatJSON,
+	}
+
+	for _, pattern := range include {
+		regEx, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Errorf("Error compiling include pattern %s: %v", pattern, err)
+		}
+
+		formatter.Include = append(formatter.Include, regEx)
+	}
+	for _, pattern := range exclude {
+		regEx, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Errorf("Error compiling exclude pattern %s: %v", pattern, err)
+		}
+
+		formatter.Exclude = append(formatter.Exclude, regEx)
+	}
+
+	return formatter, nil
+}
+
+// Format returns the formatted line and false if the line is filtered out. A line is printed if it matches at least
+// one include pattern (if there are any) and none of the exclude patterns
+func (f *LogFormatter) Format(line string) (string, bool) {
+	if f == nil {
+		return line, true
+	}
+
+	if len(f.Include) > 0 {
+		included := false
+		for _, regEx := range f.Include {
+			if regEx.MatchString(line) {
+				included = true
+				break
+			}
+		}
+		if included == false {
+			return "", false
+		}
+	}
+	for _, regEx := range f.Exclude {
+		if regEx.MatchString(line) {
+			return "", false
+		}
+	}
+
+	if f.FormatJSON {
+		if formatted, ok := formatJSONLine(line); ok {
+			return formatted, true
+		}
+	}
+
+	return line, true
+}
+
+// formatJSONLine formats a json object as `time LEVEL message key=value ...`. Lines that are no json objects are
+// not formatted
+func formatJSONLine(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "{") == false {
+		return "", false
+	}
+
+	fields := map[string]interface{}{}
+	if json.Unmarshal([]byte(trimmed), &fields) != nil {
+		return "", false
+	}
+
+	parts := []string{}
+	if key := findJSONKey(fields, jsonTimeKeys); key != "" {
+		parts = append(parts, ansi.Color(jsonValue(fields[key]), "white"))
+		delete(fields, key)
+	}
+	if key := findJSONKey(fields, jsonLevelKeys); key != "" {
+		level := jsonValue(fields[key])
+		color, ok := levelColors[strings.ToLower(level)]
+		if ok == false {
+			color = "white+b"
+		}
+
+		parts = append(parts, ansi.Color(strings.ToUpper(level), color))
+		delete(fields, key)
+	}
+	if key := findJSONKey(fields, jsonMessageKeys); key != "" {
+		parts = append(parts, jsonValue(fields[key]))
+		delete(fields, key)
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := jsonValue(fields[key])
+		if _, ok := fields[key].(string); ok && strings.ContainsAny(value, " \t\"=") {
+			value = strconv.Quote(value)
+		}
+
+		parts = append(parts, ansi.Color(key+"=", "cyan")+value)
+	}
+
+	return strings.Join(parts, " "), true
+}
+
+func findJSONKey(fields map[string]interface{}, keys []string) string {
+	for _, key := range keys {
+		if _, ok := fields[key]; ok {
+			return key
+		}
+	}
+
+	return ""
+}
+
+func jsonValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return "null"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+
+	out, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(out)
+}
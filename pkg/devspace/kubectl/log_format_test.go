@@ -0,0 +1,97 @@
+package kubectl
+
+import (
+	"testing"
+
+	"github.com/mgutz/ansi"
+
+	"gotest.tools/assert"
+)
+
+type formatTestCase struct {
+	name string
+
+	include    []string
+	exclude    []string
+	formatJSON bool
+	line       string
+
+	expectedLine    string
+	expectedPrinted bool
+}
+
+func TestFormat(t *testing.T) {
+	ansi.DisableColors(true)
+	defer ansi.DisableColors(false)
+
+	testCases := []formatTestCase{
+		formatTestCase{
+			name:            "No filters",
+			line:            "GET /healthz 200",
+			expectedLine:    "GET /healthz 200",
+			expectedPrinted: true,
+		},
+		formatTestCase{
+			name:    "Excluded",
+			exclude: []string{"healthz", "metrics"},
+			line:    "GET /healthz 200",
+		},
+		formatTestCase{
+			name:    "Not included",
+			include: []string{"^ERROR", "^WARN"},
+			line:    "INFO started",
+		},
+		formatTestCase{
+			name:            "Included",
+			include:         []string{"^ERROR", "^WARN"},
+			line:            "WARN slow query",
+			expectedLine:    "WARN slow query",
+			expectedPrinted: true,
+		},
+		formatTestCase{
+			name:            "JSON",
+			formatJSON:      true,
+			line:            `{"msg":"request done","level":"error","time":"2019-10-01T12:00:00Z","status":500,"path":"/api v1","ok":false}`,
+			expectedLine:    `2019-10-01T12:00:00Z ERROR request done ok=false path="/api v1" status=500`,
+			expectedPrinted: true,
+		},
+		formatTestCase{
+			name:            "JSON with nested fields",
+			formatJSON:      true,
+			line:            `{"message":"hello","user":{"id":1}}`,
+			expectedLine:    `hello user={"id":1}`,
+			expectedPrinted: true,
+		},
+		formatTestCase{
+			name:            "No JSON object",
+			formatJSON:      true,
+			line:            `{not json`,
+			expectedLine:    `{not json`,
+			expectedPrinted: true,
+		},
+		formatTestCase{
+			name:            "JSON not formatted",
+			line:            `{"msg":"hello"}`,
+			expectedLine:    `{"msg":"hello"}`,
+			expectedPrinted: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		formatter, err := NewLogFormatter(testCase.include, testCase.exclude, testCase.formatJSON)
+		assert.NilError(t, err, "Error creating formatter in testCase %s", testCase.name)
+
+		line, printed := formatter.Format(testCase.line)
+		assert.Equal(t, printed, testCase.expectedPrinted, "Unexpected printed in testCase %s", testCase.name)
+		assert.Equal(t, line, testCase.expectedLine, "Unexpected line in testCase %s", testCase.name)
+	}
+
+	// A nil formatter prints all lines as they are
+	var formatter *LogFormatter
+	line, printed := formatter.Format("hello")
+	assert.Equal(t, printed, true)
+	assert.Equal(t, line, "hello")
+
+	_, err := NewLogFormatter([]string{"("}, nil, false)
+	assert.Error(t, err, "Error compiling include pattern (: error parsing regexp: missing closing ): `(`")
+}
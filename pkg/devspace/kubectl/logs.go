@@ -15,6 +15,8 @@ import (
 
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 const k8sComponentLabel = "app.kubernetes.io/component"
@@ -38,6 +40,9 @@ type logLine struct {
 	line  string
 	name  string
 	color string
+
+	pod       string
+	container string
 }
 
 var colors = []string{
@@ -50,78 +55,199 @@ var colors = []string{
 	"white+b",
 }
 
+// logPollInterval is the interval in which new pods are searched for while streaming logs
+var logPollInterval = time.Second * 2
+
+// LogOptions defines the containers of which the logs are streamed by LogMultipleWithOptions. A container is
+// streamed if it matches at least one of the selectors
+type LogOptions struct {
+	// ImageSelector selects the containers that use one of the images
+	ImageSelector []string
+
+	// LabelSelector selects all containers of the pods with these labels (e.g. app=test,release=test)
+	LabelSelector string
+
+	// Deployments selects all containers of the pods of these deployments
+	Deployments []string
+
+	Namespace string
+	Tail      *int64
+
+	// Formatter filters and formats the log lines. If nil, all lines are printed as they are
+	Formatter *LogFormatter
+
+	// Watch keeps looking for new pods and containers (e.g. during a rollout) until interrupted. Otherwise the
+	// streaming stops as soon as the logs of all containers that were running at the start have ended
+	Watch bool
+
+	// NoFollow only prints the last lines of the logs instead of following them. It is ignored if Watch is true
+	NoFollow bool
+
+	// Timeout is the time to wait for selected pods to be running before the streaming starts (only without Watch)
+	Timeout time.Duration
+}
+
+// logTarget is a running container that is streamed
+type logTarget struct {
+	pod       *v1.Pod
+	container string
+	prefix    string
+}
+
+// key identifies the container instance, so that restarted containers are streamed again
+func (t *logTarget) key() string {
+	containerID := ""
+	for _, status := range t.pod.Status.ContainerStatuses {
+		if status.Name == t.container {
+			containerID = status.ContainerID
+		}
+	}
+
+	return t.pod.Namespace + "/" + t.pod.Name + "/" + t.container + "/" + containerID
+}
+
 // LogMultipleTimeout will log multiple and wait for a specific time for ready pods until timeout
 func (client *Client) LogMultipleTimeout(imageSelector []string, interrupt chan error, tail *int64, writer io.Writer, timeout time.Duration, log log.Logger) error {
-	// Get pods
-	log.StartWait("Find running pods...")
-	pods, err := client.GetRunningPodsWithImage(imageSelector, client.Namespace, timeout)
-	log.StopWait()
-	if err != nil {
-		return errors.Errorf("Error finding images: %v", err)
-	}
-	if len(pods) == 0 {
+	return client.LogMultipleWithOptions(&LogOptions{
+		ImageSelector: imageSelector,
+		Tail:          tail,
+		Timeout:       timeout,
+	}, interrupt, writer, log)
+}
+
+// LogMultiple will log multiple
+func (client *Client) LogMultiple(imageSelector []string, interrupt chan error, tail *int64, writer io.Writer, log log.Logger) error {
+	return client.LogMultipleTimeout(imageSelector, interrupt, tail, writer, time.Minute*2, log)
+}
+
+// LogMultipleWithOptions streams the logs of all containers selected by the options into the writer. Every line is
+// prefixed with the colored component label or pod name (and the container name for pods with multiple selected containers)
+func (client *Client) LogMultipleWithOptions(options *LogOptions, interrupt chan error, writer io.Writer, log log.Logger) error {
+	if len(options.ImageSelector) == 0 && options.LabelSelector == "" && len(options.Deployments) == 0 {
 		return nil
 	}
 
-	ctx, cancelFunc := context.WithCancel(context.Background())
+	tail := options.Tail
 	if tail == nil {
 		tail = ptr.Int64(100)
 	}
 
+	// Wait for the selected pods to be running
+	var targets []*logTarget
+	if options.Watch == false {
+		var err error
+
+		log.StartWait("Find running pods...")
+		targets, err = client.waitForLogTargets(options)
+		log.StopWait()
+		if err != nil {
+			return err
+		} else if len(targets) == 0 {
+			return nil
+		}
+	}
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
 	defer cancelFunc()
 
 	// Make channel buffered
 	lines := make(chan *logLine, 100)
 	done := make(chan bool)
 
-	var wg sync.WaitGroup
+	var (
+		wg        sync.WaitGroup
+		streamed  = map[string]bool{}
+		podColors = map[string]string{}
+		printInfo = true
+		initial   = true
+	)
 
-	printInfo := true
+	startStreams := func(targets []*logTarget) {
+		for _, target := range targets {
+			key := target.key()
+			if streamed[key] {
+				continue
+			}
+			streamed[key] = true
 
-	// Loop over pods and open logs connection
-	for idx, pod := range pods {
-	Outer:
-		for _, container := range pod.Spec.Containers {
-			for _, imageName := range imageSelector {
-				if imageName == container.Image {
-					reader, err := client.Logs(ctx, pod.Namespace, pod.Name, container.Name, false, tail, true)
-					if err != nil {
-						log.Warnf("Couldn't log %s/%s: %v", pod.Name, container.Name, err)
-					}
+			// Containers that start after the streaming started are streamed from the beginning
+			var targetTail *int64
+			if initial {
+				targetTail = tail
+			}
 
-					prefix := pod.Name
-					if componentLabel, ok := pod.Labels[k8sComponentLabel]; ok {
-						prefix = componentLabel
-					}
+			reader, err := client.logStream(ctx, target.pod.Namespace, target.pod.Name, target.container, targetTail, options.Watch || options.NoFollow == false)
+			if err != nil {
+				log.Warnf("Couldn't log %s/%s: %v", target.pod.Name, target.container, err)
+				continue
+			}
+
+			color, ok := podColors[target.pod.Name]
+			if ok == false {
+				color = colors[len(podColors)%len(colors)]
+				podColors[target.pod.Name] = color
+			}
+
+			if printInfo {
+				log.Info("Starting log streaming for the selected containers\n")
+				printInfo = false
+			}
+
+			wg.Add(1)
+			go func(target *logTarget, reader io.ReadCloser, color string) {
+				defer wg.Done()
+				defer reader.Close()
 
-					if printInfo {
-						log.Info("Starting log streaming for containers that use images defined in devspace.yaml\n")
-						printInfo = false
+				scanner := bufio.NewScanner(reader)
+				for scanner.Scan() {
+					lines <- &logLine{
+						line:      scanner.Text(),
+						name:      target.prefix,
+						color:     color,
+						pod:       target.pod.Name,
+						container: target.container,
 					}
+				}
+			}(target, reader, color)
+		}
 
-					wg.Add(1)
-					go func(prefix string, reader io.Reader, color string) {
-						scanner := bufio.NewScanner(reader)
-						for scanner.Scan() {
-							lines <- &logLine{
-								line:  scanner.Text(),
-								name:  prefix,
-								color: color,
-							}
-						}
-
-						wg.Done()
-					}(prefix, reader, colors[idx%len(colors)])
-					break Outer
+		initial = false
+	}
+
+	if options.Watch {
+		// Look for new pods and containers until interrupted
+		go func() {
+			for {
+				targets, _, err := client.getLogTargets(options)
+				if err != nil {
+					log.Warnf("Error searching pods for log streaming: %v", err)
+				} else {
+					startStreams(targets)
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(logPollInterval):
 				}
 			}
-		}
+		}()
+	} else {
+		startStreams(targets)
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
 	}
 
-	go func() {
-		wg.Wait()
-		close(done)
-	}()
+	writeLine := func(line *logLine) {
+		recordLogLine(line)
+
+		formatted, ok := options.Formatter.Format(line.line)
+		if ok {
+			writer.Write([]byte(ansi.Color(fmt.Sprintf("[%s]", line.name), line.color) + " " + formatted + "\n"))
+		}
+	}
 
 	for {
 		select {
@@ -129,16 +255,181 @@ func (client *Client) LogMultipleTimeout(imageSelector []string, interrupt chan
 			cancelFunc()
 			return err
 		case <-done:
-			return nil
+			// Print the lines that are still buffered
+			for {
+				select {
+				case line := <-lines:
+					writeLine(line)
+				default:
+					return nil
+				}
+			}
 		case line := <-lines:
-			writer.Write([]byte(ansi.Color(fmt.Sprintf("[%s]", line.name), line.color) + " " + line.line + "\n"))
+			writeLine(line)
 		}
 	}
 }
 
-// LogMultiple will log multiple
-func (client *Client) LogMultiple(imageSelector []string, interrupt chan error, tail *int64, writer io.Writer, log log.Logger) error {
-	return client.LogMultipleTimeout(imageSelector, interrupt, tail, writer, time.Minute*2, log)
+// recordLogLine adds the unfiltered line to the session record, if a session is recorded
+func recordLogLine(line *logLine) {
+	log.RecordContainerLog(line.pod, line.container, line.line)
+}
+
+// waitForLogTargets waits until all selected pods are running or the timeout is reached
+func (client *Client) waitForLogTargets(options *LogOptions) ([]*logTarget, error) {
+	start := time.Now()
+	for {
+		targets, pending, err := client.getLogTargets(options)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pod := range pending {
+			podStatus := GetPodStatus(pod)
+			if CriticalStatus[podStatus] {
+				return nil, errors.Errorf("Pod '%s' cannot start (Status: %s)", pod.Name, podStatus)
+			}
+		}
+
+		if len(pending) == 0 && len(targets) > 0 {
+			return targets, nil
+		} else if time.Since(start) >= options.Timeout {
+			if len(targets) > 0 {
+				return targets, nil
+			}
+
+			return nil, errors.Errorf("Waiting for running pods in namespace %s timed out", client.getLogNamespace(options))
+		}
+
+		time.Sleep(time.Second)
+	}
+}
+
+func (client *Client) getLogNamespace(options *LogOptions) string {
+	if options.Namespace != "" {
+		return options.Namespace
+	}
+
+	return client.Namespace
+}
+
+// getLogTargets returns the running containers that are selected by the options and the selected pods that are not
+// running yet. Terminating pods are ignored
+func (client *Client) getLogTargets(options *LogOptions) ([]*logTarget, []*v1.Pod, error) {
+	namespace := client.getLogNamespace(options)
+
+	selectors := []labels.Selector{}
+	if options.LabelSelector != "" {
+		selector, err := labels.Parse(options.LabelSelector)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "parse label selector %s", options.LabelSelector)
+		}
+
+		selectors = append(selectors, selector)
+	}
+	for _, name := range options.Deployments {
+		deployment, err := client.Client.AppsV1().Deployments(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "get deployment %s", name)
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "selector of deployment %s", name)
+		}
+
+		selectors = append(selectors, selector)
+	}
+
+	podList, err := client.Client.CoreV1().Pods(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	targets := []*logTarget{}
+	pending := []*v1.Pod{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		containers := []string{}
+		for _, container := range pod.Spec.Containers {
+			if isLogContainerSelected(pod, container, options.ImageSelector, selectors) {
+				containers = append(containers, container.Name)
+			}
+		}
+		if len(containers) == 0 {
+			continue
+		}
+
+		podTargets := []*logTarget{}
+		for _, container := range containers {
+			if isContainerRunning(pod, container) == false {
+				continue
+			}
+
+			prefix := pod.Name
+			if componentLabel, ok := pod.Labels[k8sComponentLabel]; ok {
+				prefix = componentLabel
+			}
+			if len(containers) > 1 {
+				prefix += ":" + container
+			}
+
+			podTargets = append(podTargets, &logTarget{
+				pod:       pod,
+				container: container,
+				prefix:    prefix,
+			})
+		}
+
+		if GetPodStatus(pod) == "Completed" {
+			continue
+		} else if len(podTargets) == 0 {
+			pending = append(pending, pod)
+			continue
+		}
+
+		targets = append(targets, podTargets...)
+	}
+
+	return targets, pending, nil
+}
+
+func isLogContainerSelected(pod *v1.Pod, container v1.Container, imageSelector []string, selectors []labels.Selector) bool {
+	for _, image := range imageSelector {
+		if image == container.Image {
+			return true
+		}
+	}
+	for _, selector := range selectors {
+		if selector.Matches(labels.Set(pod.Labels)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isContainerRunning(pod *v1.Pod, container string) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container {
+			return status.State.Running != nil
+		}
+	}
+
+	return false
+}
+
+// logStream returns the logs of the container. If tail is nil, all logs are returned
+func (client *Client) logStream(ctx context.Context, namespace, podName, containerName string, tail *int64, follow bool) (io.ReadCloser, error) {
+	return client.Client.CoreV1().Pods(namespace).GetLogs(podName, &v1.PodLogOptions{
+		Container: containerName,
+		TailLines: tail,
+		Follow:    follow,
+	}).Context(ctx).Stream()
 }
 
 // Logs prints the container logs
@@ -0,0 +1,162 @@
+package kubectl
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"gotest.tools/assert"
+)
+
+func newLogTestPod(name string, podLabels map[string]string, running bool, images ...string) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels:    podLabels,
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+		},
+	}
+
+	for index, image := range images {
+		containerName := "container-" + string(rune('a'+index))
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{
+			Name:  containerName,
+			Image: image,
+		})
+
+		state := v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ContainerCreating"}}
+		if running {
+			state = v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+		}
+
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, v1.ContainerStatus{
+			Name:        containerName,
+			ContainerID: "docker://" + name + containerName,
+			Ready:       running,
+			State:       state,
+		})
+	}
+
+	return pod
+}
+
+type getLogTargetsTestCase struct {
+	name string
+
+	options *LogOptions
+
+	expectedTargets []string
+	expectedPending []string
+}
+
+func TestGetLogTargets(t *testing.T) {
+	kubeClient := &Client{
+		Client:    fake.NewSimpleClientset(),
+		Namespace: "test",
+	}
+
+	pods := []*v1.Pod{
+		newLogTestPod("api-1", map[string]string{"app": "api"}, true, "api:latest", "sidecar:1"),
+		newLogTestPod("api-2", map[string]string{"app": "api"}, false, "api:latest", "sidecar:1"),
+		newLogTestPod("worker-1", map[string]string{"app": "worker"}, true, "worker:latest"),
+		newLogTestPod("db-1", map[string]string{"app": "db"}, true, "postgres:11"),
+		newLogTestPod("web-1", map[string]string{"app": "web", k8sComponentLabel: "web"}, true, "web:latest", "sidecar:1"),
+	}
+	for _, pod := range pods {
+		_, err := kubeClient.Client.CoreV1().Pods("test").Create(pod)
+		assert.NilError(t, err, "Error creating pod %s", pod.Name)
+	}
+
+	_, err := kubeClient.Client.AppsV1().Deployments("test").Create(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker",
+			Namespace: "test",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "worker"},
+			},
+		},
+	})
+	assert.NilError(t, err, "Error creating deployment")
+
+	testCases := []getLogTargetsTestCase{
+		getLogTargetsTestCase{
+			name: "Image selector",
+			options: &LogOptions{
+				ImageSelector: []string{"api:latest", "postgres:11"},
+			},
+			expectedTargets: []string{"api-1", "db-1"},
+			expectedPending: []string{"api-2"},
+		},
+		getLogTargetsTestCase{
+			name: "Label selector selects all containers",
+			options: &LogOptions{
+				LabelSelector: "app=api",
+			},
+			expectedTargets: []string{"api-1:container-a", "api-1:container-b"},
+			expectedPending: []string{"api-2"},
+		},
+		getLogTargetsTestCase{
+			name: "Deployments and image selector",
+			options: &LogOptions{
+				ImageSelector: []string{"postgres:11"},
+				Deployments:   []string{"worker"},
+			},
+			expectedTargets: []string{"worker-1", "db-1"},
+			expectedPending: []string{},
+		},
+		getLogTargetsTestCase{
+			name: "Component label as prefix",
+			options: &LogOptions{
+				ImageSelector: []string{"web:latest"},
+			},
+			expectedTargets: []string{"web"},
+			expectedPending: []string{},
+		},
+		getLogTargetsTestCase{
+			name: "Component label and container as prefix",
+			options: &LogOptions{
+				LabelSelector: "app=web",
+			},
+			expectedTargets: []string{"web:container-a", "web:container-b"},
+			expectedPending: []string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		targets, pending, err := kubeClient.getLogTargets(testCase.options)
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+
+		prefixes := []string{}
+		for _, target := range targets {
+			prefixes = append(prefixes, target.prefix)
+		}
+		pendingNames := []string{}
+		for _, pod := range pending {
+			pendingNames = append(pendingNames, pod.Name)
+		}
+
+		assert.DeepEqual(t, prefixes, testCase.expectedTargets)
+		assert.DeepEqual(t, pendingNames, testCase.expectedPending)
+	}
+
+	_, _, err = kubeClient.getLogTargets(&LogOptions{Deployments: []string{"unknown"}})
+	assert.Error(t, err, "get deployment unknown: deployments.apps \"unknown\" not found")
+}
+
+func TestLogTargetKey(t *testing.T) {
+	pod := newLogTestPod("api-1", nil, true, "api:latest")
+	target := &logTarget{pod: pod, container: "container-a"}
+	key := target.key()
+
+	// A restarted container has a new container id and is streamed again
+	pod.Status.ContainerStatuses[0].ContainerID = "docker://restarted"
+	assert.Assert(t, key != target.key())
+}
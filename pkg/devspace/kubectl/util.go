@@ -14,6 +14,7 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/transport/spdy"
@@ -91,11 +92,19 @@ func (client *Client) EnsureDefaultNamespace(log log.Logger) error {
 				Name: client.Namespace,
 			},
 		})
+		if err != nil {
+			// Another deployment could have created the namespace in the meantime
+			if kerrors.IsAlreadyExists(err) {
+				return nil
+			}
+
+			return err
+		}
 
 		log.Donef("Created namespace: %s", client.Namespace)
 	}
 
-	return err
+	return nil
 }
 
 // EnsureGoogleCloudClusterRoleBinding makes sure the needed cluster role is created in the google cloud or a warning is printed
@@ -0,0 +1,39 @@
+package kubectl
+
+import (
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"gotest.tools/assert"
+)
+
+func TestEnsureDefaultNamespace(t *testing.T) {
+	client := &Client{
+		Client:    fake.NewSimpleClientset(),
+		Namespace: "test-namespace",
+	}
+
+	err := client.EnsureDefaultNamespace(log.Discard)
+	assert.NilError(t, err, "Error creating namespace")
+
+	_, err = client.Client.CoreV1().Namespaces().Get("test-namespace", metav1.GetOptions{})
+	assert.NilError(t, err, "Namespace was not created")
+
+	// Simulate another deployment that created the namespace between get and create
+	fakeClient := fake.NewSimpleClientset(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace"}})
+	fakeClient.PrependReactor("get", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, kerrors.NewNotFound(v1.Resource("namespaces"), "test-namespace")
+	})
+	client.Client = fakeClient
+
+	err = client.EnsureDefaultNamespace(log.Discard)
+	assert.NilError(t, err, "Error when the namespace was created concurrently")
+}
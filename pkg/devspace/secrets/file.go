@@ -0,0 +1,61 @@
+package secrets
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// DefaultSecretsFile is the file in the devspace home folder that is used by the file store by default
+const DefaultSecretsFile = "secrets.yaml"
+
+// fileStore reads secrets from a yaml file that maps keys to values
+type fileStore struct {
+	path string
+}
+
+func newFileStore(path, basePath string) (Store, error) {
+	if path == "" {
+		homeDir, err := homedir.Dir()
+		if err != nil {
+			return nil, err
+		}
+
+		path = filepath.Join(homeDir, constants.DefaultHomeDevSpaceFolder, DefaultSecretsFile)
+	} else if filepath.IsAbs(path) == false {
+		path = filepath.Join(basePath, filepath.FromSlash(path))
+	}
+
+	return &fileStore{path: path}, nil
+}
+
+// Get implements interface
+func (f *fileStore) Get(key string) (string, error) {
+	data, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errors.Errorf("Secret file %s does not exist", f.path)
+		}
+
+		return "", err
+	}
+
+	secrets := map[string]string{}
+	err = yaml.Unmarshal(data, &secrets)
+	if err != nil {
+		return "", errors.Wrapf(err, "parse %s", f.path)
+	}
+
+	value, ok := secrets[key]
+	if !ok {
+		return "", errors.Errorf("Couldn't find secret %s in %s", key, f.path)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,34 @@
+package secrets
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testSecrets")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "secrets.yaml"), []byte("db-password: s3cret\n"), 0600)
+	assert.NilError(t, err)
+
+	store, err := NewStore("file", "secrets.yaml", dir)
+	assert.NilError(t, err)
+
+	value, err := store.Get("db-password")
+	assert.NilError(t, err)
+	assert.Equal(t, value, "s3cret")
+
+	_, err = store.Get("other")
+	assert.Error(t, err, "Couldn't find secret other in "+filepath.Join(dir, "secrets.yaml"))
+
+	_, err = NewStore("vault", "", dir)
+	assert.Error(t, err, "Unknown secret store vault, please use one of: file, pass")
+}
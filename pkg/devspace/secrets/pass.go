@@ -0,0 +1,45 @@
+package secrets
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// passStore reads secrets from the standard unix password manager (https://www.passwordstore.org)
+type passStore struct {
+	storeDir string
+}
+
+func newPassStore(path, basePath string) (Store, error) {
+	if path != "" && filepath.IsAbs(path) == false {
+		path = filepath.Join(basePath, filepath.FromSlash(path))
+	}
+
+	return &passStore{storeDir: path}, nil
+}
+
+// Get implements interface
+func (p *passStore) Get(key string) (string, error) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd := exec.Command("pass", "show", key)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if p.storeDir != "" {
+		cmd.Env = append(os.Environ(), "PASSWORD_STORE_DIR="+p.storeDir)
+	}
+
+	err := cmd.Run()
+	if err != nil {
+		return "", errors.Errorf("pass show %s: %v %s", key, err, strings.TrimSpace(stderr.String()))
+	}
+
+	// By convention the password is stored in the first line
+	return strings.SplitN(stdout.String(), "\n", 2)[0], nil
+}
@@ -0,0 +1,68 @@
+package secrets
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultStore is the store that is used if a variable does not specify one
+const DefaultStore = "file"
+
+// Store retrieves secrets from a secret backend
+type Store interface {
+	Get(key string) (string, error)
+}
+
+// Factory creates a new store. Path is the optional location of the store and basePath the folder of the config
+// that relative paths are resolved against
+type Factory func(path, basePath string) (Store, error)
+
+var (
+	stores      = map[string]Factory{}
+	storesMutex sync.RWMutex
+)
+
+func init() {
+	Register("file", newFileStore)
+	Register("pass", newPassStore)
+}
+
+// Register makes a store available under the given name
+func Register(name string, factory Factory) {
+	storesMutex.Lock()
+	defer storesMutex.Unlock()
+
+	stores[strings.ToLower(name)] = factory
+}
+
+// NewStore creates the store with the given name
+func NewStore(name, path, basePath string) (Store, error) {
+	if name == "" {
+		name = DefaultStore
+	}
+
+	storesMutex.RLock()
+	factory, ok := stores[strings.ToLower(name)]
+	storesMutex.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("Unknown secret store %s, please use one of: %s", name, strings.Join(storeNames(), ", "))
+	}
+
+	return factory(path, basePath)
+}
+
+func storeNames() []string {
+	storesMutex.RLock()
+	defer storesMutex.RUnlock()
+
+	names := make([]string, 0, len(stores))
+	for name := range stores {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
@@ -160,7 +160,7 @@ func newHandler(config *latest.Config, generatedConfig *generated.Config, defaul
 	// Load raw config
 	if config != nil {
 		configPath := filepath.Join(cwd, constants.DefaultConfigPath)
-		handler.rawConfig, err = configutil.GetRawConfig(configPath)
+		handler.rawConfig, err = configutil.GetRawConfigWithImports(configPath)
 		if err != nil {
 			return nil, errors.Wrap(err, "load raw config")
 		}
@@ -0,0 +1,295 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	"github.com/devspace-cloud/devspace/pkg/util/randutil"
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubectlExec "k8s.io/client-go/util/exec"
+)
+
+// DebugPodLabel is the label of the pod copies that are created for debug containers. The value is the name of the
+// original pod
+const DebugPodLabel = "devspace.cloud/debug"
+
+// debugContainerPrefix is the prefix of the names of the debug containers
+const debugContainerPrefix = "devspace-debug-"
+
+// debugContainerTimeout is the maximum time to wait for a debug container to start
+var debugContainerTimeout = time.Minute * 2
+
+// debugContainerStatus holds the fields of the pod status that are needed to wait for an ephemeral container. The
+// vendored kubernetes api types do not know ephemeral containers yet, so the pod is read as raw json
+type debugContainerStatus struct {
+	Status struct {
+		EphemeralContainerStatuses []v1.ContainerStatus `json:"ephemeralContainerStatuses,omitempty"`
+	} `json:"status"`
+}
+
+// StartDebugTerminal opens a terminal in a debug container with the given image that shares the process namespace
+// with the selected container. An ephemeral container is attached to the selected pod if the cluster supports
+// ephemeral containers. Otherwise a copy of the pod with a debug sidecar is created and deleted afterwards
+func StartDebugTerminal(client *kubectl.Client, selectorParameter *targetselector.SelectorParameter, image string, args []string, log log.Logger) (int, error) {
+	targetSelector, err := targetselector.NewTargetSelector(nil, client, selectorParameter, true, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	targetSelector.AllowNonRunning = true
+	targetSelector.PodQuestion = ptr.String("Which pod do you want to debug?")
+
+	pod, container, err := targetSelector.GetContainer(false, log)
+	if err != nil {
+		return 0, err
+	}
+
+	suffix, err := randutil.GenerateRandomString(5)
+	if err != nil {
+		return 0, err
+	}
+
+	// Container names must be lowercase
+	debugContainer := getDebugContainer(debugContainerPrefix+strings.ToLower(suffix), image, getCommand(nil, args))
+
+	log.StartWait("Starting debug container...")
+	debugPod, err := createEphemeralContainer(client, pod, container.Name, debugContainer)
+	if err == errEphemeralContainersNotSupported {
+		log.StopWait()
+		log.Infof("The cluster doesn't support ephemeral containers. Creating a copy of pod %s with a debug container instead", pod.Name)
+		log.StartWait("Starting pod copy with debug container...")
+
+		debugPod, err = createDebugPodCopy(client, pod, debugContainer)
+		if debugPod != nil {
+			defer func() {
+				deleteErr := client.Client.CoreV1().Pods(debugPod.Namespace).Delete(debugPod.Name, &metav1.DeleteOptions{GracePeriodSeconds: ptr.Int64(0)})
+				if deleteErr != nil && kerrors.IsNotFound(deleteErr) == false {
+					log.Warnf("Error deleting pod %s: %v", debugPod.Name, deleteErr)
+				}
+			}()
+		}
+	}
+	log.StopWait()
+	if err != nil {
+		return 0, err
+	}
+
+	wrapper, upgradeRoundTripper, err := kubectl.GetUpgraderWrapper(client.RestConfig)
+	if err != nil {
+		return 0, err
+	}
+
+	log.WriteString("\n")
+	log.Infof("Opening shell to debug container %s in pod %s (image: %s)", ansi.Color(debugContainer.Name, "white+b"), ansi.Color(debugPod.Name, "white+b"), image)
+	log.Info("If you don't see a command prompt, try pressing enter.")
+	log.WriteString("\n")
+
+	err = client.ExecStreamWithTransport(wrapper, upgradeRoundTripper, debugPod, debugContainer.Name, nil, true, os.Stdin, os.Stdout, os.Stderr, kubectl.SubResourceAttach)
+	upgradeRoundTripper.Close()
+	if err != nil {
+		if exitError, ok := err.(kubectlExec.CodeExitError); ok {
+			return exitError.Code, nil
+		}
+
+		return 0, err
+	}
+
+	return 0, nil
+}
+
+// getDebugContainer returns the debug container that runs the command with a terminal attached
+func getDebugContainer(name, image string, command []string) *v1.Container {
+	return &v1.Container{
+		Name:    name,
+		Image:   image,
+		Command: command,
+		Stdin:   true,
+		TTY:     true,
+	}
+}
+
+var errEphemeralContainersNotSupported = errors.New("ephemeral containers are not supported")
+
+// createEphemeralContainer adds the debug container as ephemeral container to the pod and waits until it is running.
+// If the cluster doesn't support ephemeral containers, errEphemeralContainersNotSupported is returned
+func createEphemeralContainer(client *kubectl.Client, pod *v1.Pod, targetContainer string, container *v1.Container) (*v1.Pod, error) {
+	patch, err := getEphemeralContainerPatch(container, targetContainer)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.Client.CoreV1().RESTClient().Patch(types.StrategicMergePatchType).
+		Namespace(pod.Namespace).
+		Resource("pods").
+		Name(pod.Name).
+		SubResource("ephemeralcontainers").
+		Body(patch).
+		DoRaw()
+	if err != nil {
+		if kerrors.IsNotFound(err) || kerrors.IsMethodNotSupported(err) || kerrors.IsBadRequest(err) || kerrors.IsUnsupportedMediaType(err) {
+			return nil, errEphemeralContainersNotSupported
+		}
+
+		return nil, errors.Wrap(err, "create ephemeral container")
+	}
+
+	// Clusters with alpha ephemeral containers ignore the patch, because they expect a different object
+	if ephemeralContainerExists(result, container.Name) == false {
+		return nil, errEphemeralContainersNotSupported
+	}
+
+	for start := time.Now(); time.Since(start) < debugContainerTimeout; time.Sleep(time.Second) {
+		raw, err := client.Client.CoreV1().RESTClient().Get().
+			Namespace(pod.Namespace).
+			Resource("pods").
+			Name(pod.Name).
+			DoRaw()
+		if err != nil {
+			return nil, errors.Wrapf(err, "get pod %s", pod.Name)
+		}
+
+		running, err := isEphemeralContainerRunning(raw, container.Name)
+		if err != nil {
+			return nil, err
+		} else if running {
+			return pod, nil
+		}
+	}
+
+	return nil, errors.Errorf("Timeout waiting for debug container %s to start", container.Name)
+}
+
+// getEphemeralContainerPatch returns the patch that adds the ephemeral container to the pod. The container shares
+// the process namespace with the target container
+func getEphemeralContainerPatch(container *v1.Container, targetContainer string) ([]byte, error) {
+	ephemeralContainer := map[string]interface{}{
+		"name":                container.Name,
+		"image":               container.Image,
+		"command":             container.Command,
+		"stdin":               container.Stdin,
+		"tty":                 container.TTY,
+		"targetContainerName": targetContainer,
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"ephemeralContainers": []interface{}{ephemeralContainer},
+		},
+	})
+}
+
+func ephemeralContainerExists(rawPod []byte, name string) bool {
+	pod := struct {
+		Spec struct {
+			EphemeralContainers []v1.Container `json:"ephemeralContainers,omitempty"`
+		} `json:"spec"`
+	}{}
+	if json.Unmarshal(rawPod, &pod) != nil {
+		return false
+	}
+
+	for _, container := range pod.Spec.EphemeralContainers {
+		if container.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isEphemeralContainerRunning checks the status of the ephemeral container in the raw pod and returns an error if
+// the container couldn't be started
+func isEphemeralContainerRunning(rawPod []byte, name string) (bool, error) {
+	pod := &debugContainerStatus{}
+	err := json.Unmarshal(rawPod, pod)
+	if err != nil {
+		return false, errors.Wrap(err, "parse pod")
+	}
+
+	for _, status := range pod.Status.EphemeralContainerStatuses {
+		if status.Name != name {
+			continue
+		}
+
+		if status.State.Running != nil {
+			return true, nil
+		} else if status.State.Terminated != nil {
+			return false, errors.Errorf("Debug container %s terminated: %s (exit code %d)", name, status.State.Terminated.Reason, status.State.Terminated.ExitCode)
+		} else if status.State.Waiting != nil && isImagePullError(status.State.Waiting.Reason) {
+			return false, errors.Errorf("Debug container %s couldn't start: %s %s", name, status.State.Waiting.Reason, status.State.Waiting.Message)
+		}
+	}
+
+	return false, nil
+}
+
+func isImagePullError(reason string) bool {
+	return reason == "ErrImagePull" || reason == "ImagePullBackOff" || reason == "InvalidImageName"
+}
+
+// createDebugPodCopy creates a copy of the pod with the debug container as sidecar that shares the process namespace
+// with the other containers and waits until the copy is running. The copy has no labels of the original pod, so
+// that neither services nor controllers select it
+func createDebugPodCopy(client *kubectl.Client, pod *v1.Pod, container *v1.Container) (*v1.Pod, error) {
+	debugPod, err := client.Client.CoreV1().Pods(pod.Namespace).Create(getDebugPodCopy(pod, container))
+	if err != nil {
+		return nil, errors.Wrap(err, "create pod copy")
+	}
+
+	for start := time.Now(); time.Since(start) < debugContainerTimeout; time.Sleep(time.Second) {
+		current, err := client.Client.CoreV1().Pods(debugPod.Namespace).Get(debugPod.Name, metav1.GetOptions{})
+		if err != nil {
+			return debugPod, errors.Wrapf(err, "get pod %s", debugPod.Name)
+		}
+
+		for _, status := range current.Status.ContainerStatuses {
+			if status.Name != container.Name {
+				continue
+			}
+
+			if status.State.Running != nil {
+				return current, nil
+			} else if status.State.Terminated != nil {
+				return debugPod, errors.Errorf("Debug container %s terminated: %s (exit code %d)", container.Name, status.State.Terminated.Reason, status.State.Terminated.ExitCode)
+			} else if status.State.Waiting != nil && isImagePullError(status.State.Waiting.Reason) {
+				return debugPod, errors.Errorf("Debug container %s couldn't start: %s %s", container.Name, status.State.Waiting.Reason, status.State.Waiting.Message)
+			}
+		}
+	}
+
+	return debugPod, errors.Errorf("Timeout waiting for pod %s to start", debugPod.Name)
+}
+
+// getDebugPodCopy returns the copy of the pod with the debug container
+func getDebugPodCopy(pod *v1.Pod, container *v1.Container) *v1.Pod {
+	debugPod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pod.Name + "-" + container.Name,
+			Namespace:   pod.Namespace,
+			Labels:      map[string]string{DebugPodLabel: pod.Name},
+			Annotations: pod.Annotations,
+		},
+		Spec: *pod.Spec.DeepCopy(),
+	}
+
+	// Let the scheduler pick a node and don't restart the containers of the copy
+	debugPod.Spec.NodeName = ""
+	debugPod.Spec.RestartPolicy = v1.RestartPolicyNever
+	if debugPod.Spec.HostPID == false {
+		debugPod.Spec.ShareProcessNamespace = ptr.Bool(true)
+	}
+
+	debugPod.Spec.Containers = append(debugPod.Spec.Containers, *container)
+	return debugPod
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"gotest.tools/assert"
+)
+
+type isEphemeralContainerRunningTestCase struct {
+	name string
+
+	rawPod string
+
+	expectedRunning bool
+	expectedErr     string
+}
+
+func TestIsEphemeralContainerRunning(t *testing.T) {
+	testCases := []isEphemeralContainerRunningTestCase{
+		isEphemeralContainerRunningTestCase{
+			name:   "No status yet",
+			rawPod: `{"status":{}}`,
+		},
+		isEphemeralContainerRunningTestCase{
+			name:            "Running",
+			rawPod:          `{"status":{"ephemeralContainerStatuses":[{"name":"other","state":{"waiting":{}}},{"name":"debug","state":{"running":{}}}]}}`,
+			expectedRunning: true,
+		},
+		isEphemeralContainerRunningTestCase{
+			name:   "Creating",
+			rawPod: `{"status":{"ephemeralContainerStatuses":[{"name":"debug","state":{"waiting":{"reason":"ContainerCreating"}}}]}}`,
+		},
+		isEphemeralContainerRunningTestCase{
+			name:        "Image pull error",
+			rawPod:      `{"status":{"ephemeralContainerStatuses":[{"name":"debug","state":{"waiting":{"reason":"ErrImagePull","message":"not found"}}}]}}`,
+			expectedErr: "Debug container debug couldn't start: ErrImagePull not found",
+		},
+		isEphemeralContainerRunningTestCase{
+			name:        "Terminated",
+			rawPod:      `{"status":{"ephemeralContainerStatuses":[{"name":"debug","state":{"terminated":{"reason":"Error","exitCode":127}}}]}}`,
+			expectedErr: "Debug container debug terminated: Error (exit code 127)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		running, err := isEphemeralContainerRunning([]byte(testCase.rawPod), "debug")
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+		}
+
+		assert.Equal(t, running, testCase.expectedRunning, "Unexpected running state in testCase %s", testCase.name)
+	}
+}
+
+func TestGetEphemeralContainerPatch(t *testing.T) {
+	patch, err := getEphemeralContainerPatch(getDebugContainer("debug", "busybox", []string{"sh"}), "app")
+	assert.NilError(t, err, "Error creating patch")
+	assert.Equal(t, ephemeralContainerExists(patch, "debug"), true)
+	assert.Equal(t, ephemeralContainerExists(patch, "other"), false)
+
+	parsed := map[string]map[string][]map[string]interface{}{}
+	err = json.Unmarshal(patch, &parsed)
+	assert.NilError(t, err, "Error parsing patch")
+	assert.Equal(t, parsed["spec"]["ephemeralContainers"][0]["targetContainerName"], "app")
+	assert.Equal(t, parsed["spec"]["ephemeralContainers"][0]["tty"], true)
+}
+
+func TestGetDebugPodCopy(t *testing.T) {
+	pod := &k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "api-5d8f",
+			Namespace:       "test",
+			Labels:          map[string]string{"app": "api"},
+			ResourceVersion: "123",
+			OwnerReferences: []metav1.OwnerReference{{Name: "api"}},
+		},
+		Spec: k8sv1.PodSpec{
+			NodeName: "node-1",
+			Containers: []k8sv1.Container{
+				{
+					Name:  "api",
+					Image: "gcr.io/distroless/base",
+				},
+			},
+		},
+	}
+
+	debugPod := getDebugPodCopy(pod, getDebugContainer("devspace-debug-abcde", "busybox", []string{"sh"}))
+	assert.Equal(t, debugPod.Name, "api-5d8f-devspace-debug-abcde")
+	assert.Equal(t, debugPod.Namespace, "test")
+	assert.DeepEqual(t, debugPod.Labels, map[string]string{DebugPodLabel: "api-5d8f"})
+	assert.Equal(t, debugPod.ResourceVersion, "")
+	assert.Equal(t, len(debugPod.OwnerReferences), 0)
+	assert.Equal(t, debugPod.Spec.NodeName, "")
+	assert.DeepEqual(t, debugPod.Spec.ShareProcessNamespace, ptr.Bool(true))
+	assert.Equal(t, len(debugPod.Spec.Containers), 2)
+	assert.Equal(t, debugPod.Spec.Containers[1].Image, "busybox")
+
+	// The original pod is not changed
+	assert.Equal(t, len(pod.Spec.Containers), 1)
+	assert.Equal(t, pod.Spec.NodeName, "node-1")
+}
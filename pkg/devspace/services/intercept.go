@@ -0,0 +1,405 @@
+package services
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// InterceptLabel is the label of the proxy pod that replaces the pods of an intercepted deployment
+const InterceptLabel = "devspace.cloud/intercept"
+
+// InterceptReplicasAnnotation holds the replicas an intercepted deployment had before it was scaled down
+const InterceptReplicasAnnotation = "devspace.cloud/intercept-replicas"
+
+// DefaultInterceptImage is the image of the proxy pod. The image needs tar to inject the helper
+const DefaultInterceptImage = "alpine:3.10"
+
+// interceptContainer is the name of the container in the proxy pod
+const interceptContainer = "proxy"
+
+// interceptRetryInterval is the time to wait before a broken tunnel is restarted
+var interceptRetryInterval = time.Second * 2
+
+// InterceptOptions defines which deployment is intercepted and which ports are forwarded to the local machine
+type InterceptOptions struct {
+	Deployment string
+	Namespace  string
+	Image      string
+
+	// Ports are mappings in the form local:remote or port. If empty, the target ports of all services
+	// that select the pods of the deployment are forwarded to the same local ports
+	Ports []string
+}
+
+// StartIntercept replaces the pods of the deployment with a proxy pod that forwards the traffic for the service ports
+// to the local machine. It blocks until the stop channel is closed and restores the deployment afterwards
+func StartIntercept(client *kubectl.Client, options *InterceptOptions, stopChan chan struct{}, log log.Logger) (err error) {
+	namespace := options.Namespace
+	if namespace == "" {
+		namespace = client.Namespace
+	}
+
+	deployment, err := client.Client.AppsV1().Deployments(namespace).Get(options.Deployment, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "get deployment %s", options.Deployment)
+	}
+
+	var mappings []*reverseMapping
+	if len(options.Ports) > 0 {
+		mappings, err = parseInterceptPorts(options.Ports)
+	} else {
+		var services *v1.ServiceList
+		services, err = client.Client.CoreV1().Services(namespace).List(metav1.ListOptions{})
+		if err != nil {
+			return errors.Wrap(err, "list services")
+		}
+
+		mappings, err = getInterceptMappings(deployment, services.Items)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Create the proxy pod
+	log.StartWait("Intercept: Starting proxy pod...")
+	pod, err := createInterceptPod(client, deployment, options.Image, mappings)
+	if err != nil {
+		log.StopWait()
+		return err
+	}
+	defer func() {
+		deleteErr := client.Client.CoreV1().Pods(namespace).Delete(pod.Name, &metav1.DeleteOptions{GracePeriodSeconds: ptr.Int64(0)})
+		if deleteErr != nil && kerrors.IsNotFound(deleteErr) == false {
+			log.Warnf("Error deleting proxy pod %s: %v", pod.Name, deleteErr)
+		}
+	}()
+
+	selector, err := targetselector.NewTargetSelector(nil, client, &targetselector.SelectorParameter{
+		CmdParameter: targetselector.CmdParameter{
+			Namespace:     namespace,
+			LabelSelector: InterceptLabel + "=" + deployment.Name,
+		},
+	}, false, nil)
+	if err != nil {
+		log.StopWait()
+		return err
+	}
+
+	pod, err = selector.GetPod(log)
+	log.StopWait()
+	if err != nil {
+		return errors.Wrap(err, "wait for proxy pod")
+	} else if pod == nil {
+		return errors.New("Proxy pod didn't start")
+	}
+
+	err = injectSync(client, pod, interceptContainer)
+	if err != nil {
+		return errors.Wrap(err, "inject helper")
+	}
+
+	// Start the tunnels before the original pods are removed
+	tunnelStopChan := make(chan struct{})
+	defer close(tunnelStopChan)
+
+	for _, mapping := range mappings {
+		readyChan := make(chan struct{})
+		go runInterceptTunnel(client, pod, mapping, readyChan, tunnelStopChan, log)
+
+		select {
+		case <-readyChan:
+		case <-time.After(readyTimeout):
+			return errors.Errorf("Timeout waiting for tunnel %s to start", mapping.String())
+		}
+	}
+
+	// Scale down the deployment and restore it when the intercept stops
+	replicas, err := scaleDownDeployment(client, namespace, deployment.Name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		restoreErr := restoreDeployment(client, namespace, deployment.Name, replicas)
+		if restoreErr != nil {
+			if err == nil {
+				err = restoreErr
+			} else {
+				log.Warnf("Error restoring deployment %s: %v", deployment.Name, restoreErr)
+			}
+
+			return
+		}
+
+		log.Donef("Restored deployment %s with %d replicas", deployment.Name, replicas)
+	}()
+
+	ports := make([]string, 0, len(mappings))
+	for _, mapping := range mappings {
+		ports = append(ports, mapping.String())
+	}
+
+	log.Donef("Intercepting deployment %s: %s", deployment.Name, strings.Join(ports, ", "))
+	log.Info("Press Ctrl+C to stop the intercept and restore the deployment")
+
+	<-stopChan
+	return nil
+}
+
+// runInterceptTunnel runs the tunnel for the mapping and restarts it if it breaks until the stop channel is closed
+func runInterceptTunnel(client *kubectl.Client, pod *v1.Pod, mapping *reverseMapping, readyChan, stopChan chan struct{}, log log.Logger) {
+	for {
+		err := reverseForward(client, pod, interceptContainer, mapping, readyChan, stopChan)
+		select {
+		case <-stopChan:
+			return
+		default:
+		}
+
+		if err != nil {
+			log.Warnf("Intercept: Tunnel %s broke: %v. Restarting...", mapping.String(), err)
+		}
+
+		select {
+		case <-stopChan:
+			return
+		case <-time.After(interceptRetryInterval):
+		}
+
+		readyChan = make(chan struct{})
+	}
+}
+
+// getInterceptMappings returns a mapping for every target port of the services that select the pods of the deployment.
+// The proxy listens on the target port and forwards the traffic to the same port on the local machine
+func getInterceptMappings(deployment *appsv1.Deployment, services []v1.Service) ([]*reverseMapping, error) {
+	podLabels := labels.Set(deployment.Spec.Template.Labels)
+
+	ports := map[int]bool{}
+	for _, service := range services {
+		if len(service.Spec.Selector) == 0 || labels.SelectorFromSet(service.Spec.Selector).Matches(podLabels) == false {
+			continue
+		}
+
+		for _, servicePort := range service.Spec.Ports {
+			if servicePort.Protocol != "" && servicePort.Protocol != v1.ProtocolTCP {
+				continue
+			}
+
+			port, err := resolveTargetPort(deployment, servicePort)
+			if err != nil {
+				return nil, errors.Wrapf(err, "service %s", service.Name)
+			}
+
+			ports[port] = true
+		}
+	}
+	if len(ports) == 0 {
+		return nil, errors.Errorf("No service selects the pods of deployment %s. Please specify the ports to forward with --port", deployment.Name)
+	}
+
+	sortedPorts := make([]int, 0, len(ports))
+	for port := range ports {
+		sortedPorts = append(sortedPorts, port)
+	}
+	sort.Ints(sortedPorts)
+
+	mappings := make([]*reverseMapping, 0, len(sortedPorts))
+	for _, port := range sortedPorts {
+		mappings = append(mappings, &reverseMapping{
+			localAddress:  net.JoinHostPort("127.0.0.1", strconv.Itoa(port)),
+			remoteAddress: net.JoinHostPort("0.0.0.0", strconv.Itoa(port)),
+		})
+	}
+
+	return mappings, nil
+}
+
+// resolveTargetPort returns the container port the service port targets in the pods of the deployment
+func resolveTargetPort(deployment *appsv1.Deployment, servicePort v1.ServicePort) (int, error) {
+	if servicePort.TargetPort.Type == intstr.Int {
+		if servicePort.TargetPort.IntVal == 0 {
+			return int(servicePort.Port), nil
+		}
+
+		return int(servicePort.TargetPort.IntVal), nil
+	}
+
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == servicePort.TargetPort.StrVal {
+				return int(containerPort.ContainerPort), nil
+			}
+		}
+	}
+
+	return 0, errors.Errorf("Couldn't find a container port named %s in deployment %s", servicePort.TargetPort.StrVal, deployment.Name)
+}
+
+// parseInterceptPorts parses port mappings in the form local:remote or port
+func parseInterceptPorts(ports []string) ([]*reverseMapping, error) {
+	mappings := make([]*reverseMapping, 0, len(ports))
+	for _, port := range ports {
+		splitted := strings.Split(port, ":")
+		if len(splitted) > 2 {
+			return nil, errors.Errorf("Error parsing port %s: expected local:remote or port", port)
+		}
+
+		for _, value := range splitted {
+			if _, err := strconv.Atoi(strings.TrimSpace(value)); err != nil {
+				return nil, errors.Errorf("Error parsing port %s: %s is not a number", port, value)
+			}
+		}
+
+		localPort := strings.TrimSpace(splitted[0])
+		remotePort := localPort
+		if len(splitted) == 2 {
+			remotePort = strings.TrimSpace(splitted[1])
+		}
+
+		mappings = append(mappings, &reverseMapping{
+			localAddress:  net.JoinHostPort("127.0.0.1", localPort),
+			remoteAddress: net.JoinHostPort("0.0.0.0", remotePort),
+		})
+	}
+
+	return mappings, nil
+}
+
+// createInterceptPod creates the proxy pod, which has the same labels as the pods of the deployment, so that the
+// services of the deployment select it
+func createInterceptPod(client *kubectl.Client, deployment *appsv1.Deployment, image string, mappings []*reverseMapping) (*v1.Pod, error) {
+	if image == "" {
+		image = DefaultInterceptImage
+	}
+
+	podLabels := map[string]string{}
+	for key, value := range deployment.Spec.Template.Labels {
+		podLabels[key] = value
+	}
+	podLabels[InterceptLabel] = deployment.Name
+
+	// Keep the names of the container ports, so that services with named target ports still work
+	portNames := map[int32]string{}
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			portNames[containerPort.ContainerPort] = containerPort.Name
+		}
+	}
+
+	containerPorts := []v1.ContainerPort{}
+	for _, mapping := range mappings {
+		_, port, _ := net.SplitHostPort(mapping.remoteAddress)
+		portNumber, _ := strconv.Atoi(port)
+
+		containerPorts = append(containerPorts, v1.ContainerPort{
+			Name:          portNames[int32(portNumber)],
+			ContainerPort: int32(portNumber),
+		})
+	}
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   deployment.Name + "-intercept",
+			Labels: podLabels,
+		},
+		Spec: v1.PodSpec{
+			TerminationGracePeriodSeconds: ptr.Int64(1),
+			Containers: []v1.Container{
+				{
+					Name:    interceptContainer,
+					Image:   image,
+					Command: []string{"sh", "-c", "trap 'exit 0' TERM INT; while true; do sleep 1; done"},
+					Ports:   containerPorts,
+				},
+			},
+		},
+	}
+
+	// Remove a proxy pod of an intercept that didn't stop properly
+	err := client.Client.CoreV1().Pods(deployment.Namespace).Delete(pod.Name, &metav1.DeleteOptions{GracePeriodSeconds: ptr.Int64(0)})
+	if err != nil && kerrors.IsNotFound(err) == false {
+		return nil, errors.Wrapf(err, "delete pod %s", pod.Name)
+	} else if err == nil {
+		for i := 0; i < 30; i++ {
+			_, err = client.Client.CoreV1().Pods(deployment.Namespace).Get(pod.Name, metav1.GetOptions{})
+			if kerrors.IsNotFound(err) {
+				break
+			}
+
+			time.Sleep(time.Second)
+		}
+	}
+
+	pod, err = client.Client.CoreV1().Pods(deployment.Namespace).Create(pod)
+	if err != nil {
+		return nil, errors.Wrap(err, "create proxy pod")
+	}
+
+	return pod, nil
+}
+
+// scaleDownDeployment scales the deployment to 0 and returns the replicas it had before. The replicas are stored in
+// an annotation, so that an intercept that didn't stop properly can be restored by the next intercept
+func scaleDownDeployment(client *kubectl.Client, namespace, name string) (int32, error) {
+	deployment, err := client.Client.AppsV1().Deployments(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return 0, errors.Wrapf(err, "get deployment %s", name)
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if deployment.Annotations[InterceptReplicasAnnotation] != "" {
+		original, err := strconv.Atoi(deployment.Annotations[InterceptReplicasAnnotation])
+		if err == nil {
+			replicas = int32(original)
+		}
+	}
+
+	if deployment.Annotations == nil {
+		deployment.Annotations = map[string]string{}
+	}
+	deployment.Annotations[InterceptReplicasAnnotation] = strconv.Itoa(int(replicas))
+	deployment.Spec.Replicas = ptr.Int32(0)
+
+	_, err = client.Client.AppsV1().Deployments(namespace).Update(deployment)
+	if err != nil {
+		return 0, errors.Wrapf(err, "scale down deployment %s", name)
+	}
+
+	return replicas, nil
+}
+
+// restoreDeployment scales the deployment back to the given replicas
+func restoreDeployment(client *kubectl.Client, namespace, name string, replicas int32) error {
+	deployment, err := client.Client.AppsV1().Deployments(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "get deployment %s", name)
+	}
+
+	delete(deployment.Annotations, InterceptReplicasAnnotation)
+	deployment.Spec.Replicas = &replicas
+
+	_, err = client.Client.AppsV1().Deployments(namespace).Update(deployment)
+	if err != nil {
+		return errors.Wrapf(err, "restore deployment %s", name)
+	}
+
+	return nil
+}
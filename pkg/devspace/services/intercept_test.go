@@ -0,0 +1,169 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"gotest.tools/assert"
+)
+
+type getInterceptMappingsTestCase struct {
+	name string
+
+	services []k8sv1.Service
+
+	expectedMappings []string
+	expectedErr      string
+}
+
+func newInterceptService(name string, selector map[string]string, ports ...k8sv1.ServicePort) k8sv1.Service {
+	return k8sv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: k8sv1.ServiceSpec{
+			Selector: selector,
+			Ports:    ports,
+		},
+	}
+}
+
+func TestGetInterceptMappings(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "api",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: k8sv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "api", "tier": "backend"},
+				},
+				Spec: k8sv1.PodSpec{
+					Containers: []k8sv1.Container{
+						{
+							Name: "api",
+							Ports: []k8sv1.ContainerPort{
+								{
+									Name:          "http",
+									ContainerPort: 8080,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []getInterceptMappingsTestCase{
+		getInterceptMappingsTestCase{
+			name: "Service with named and numbered target ports",
+			services: []k8sv1.Service{
+				newInterceptService("api", map[string]string{"app": "api"},
+					k8sv1.ServicePort{Port: 80, TargetPort: intstr.FromString("http")},
+					k8sv1.ServicePort{Port: 9090, TargetPort: intstr.FromInt(9091)},
+					k8sv1.ServicePort{Port: 5000},
+				),
+				newInterceptService("other", map[string]string{"app": "other"},
+					k8sv1.ServicePort{Port: 3000},
+				),
+			},
+			expectedMappings: []string{"0.0.0.0:5000 -> 127.0.0.1:5000", "0.0.0.0:8080 -> 127.0.0.1:8080", "0.0.0.0:9091 -> 127.0.0.1:9091"},
+		},
+		getInterceptMappingsTestCase{
+			name: "No matching service",
+			services: []k8sv1.Service{
+				newInterceptService("other", map[string]string{"app": "api", "tier": "frontend"},
+					k8sv1.ServicePort{Port: 3000},
+				),
+			},
+			expectedErr: "No service selects the pods of deployment api. Please specify the ports to forward with --port",
+		},
+		getInterceptMappingsTestCase{
+			name: "Unknown named port",
+			services: []k8sv1.Service{
+				newInterceptService("api", map[string]string{"app": "api"},
+					k8sv1.ServicePort{Port: 80, TargetPort: intstr.FromString("grpc")},
+				),
+			},
+			expectedErr: "service api: Couldn't find a container port named grpc in deployment api",
+		},
+	}
+
+	for _, testCase := range testCases {
+		mappings, err := getInterceptMappings(deployment, testCase.services)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+		}
+
+		mappingStrings := []string{}
+		for _, mapping := range mappings {
+			mappingStrings = append(mappingStrings, mapping.String())
+		}
+		if testCase.expectedMappings == nil {
+			testCase.expectedMappings = []string{}
+		}
+
+		assert.DeepEqual(t, mappingStrings, testCase.expectedMappings)
+	}
+}
+
+func TestParseInterceptPorts(t *testing.T) {
+	mappings, err := parseInterceptPorts([]string{"3000:8080", "9229"})
+	assert.NilError(t, err, "Error parsing ports")
+	assert.Equal(t, len(mappings), 2)
+	assert.Equal(t, mappings[0].String(), "0.0.0.0:8080 -> 127.0.0.1:3000")
+	assert.Equal(t, mappings[1].String(), "0.0.0.0:9229 -> 127.0.0.1:9229")
+
+	_, err = parseInterceptPorts([]string{"http"})
+	assert.Error(t, err, "Error parsing port http: http is not a number")
+}
+
+func TestScaleDownAndRestoreDeployment(t *testing.T) {
+	namespace := "test"
+	kubeClient := &kubectl.Client{
+		Client:    fake.NewSimpleClientset(),
+		Namespace: namespace,
+	}
+
+	_, err := kubeClient.Client.AppsV1().Deployments(namespace).Create(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api",
+			Namespace: namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.Int32(3),
+		},
+	})
+	assert.NilError(t, err, "Error creating deployment")
+
+	replicas, err := scaleDownDeployment(kubeClient, namespace, "api")
+	assert.NilError(t, err, "Error scaling down deployment")
+	assert.Equal(t, replicas, int32(3))
+
+	deployment, _ := kubeClient.Client.AppsV1().Deployments(namespace).Get("api", metav1.GetOptions{})
+	assert.Equal(t, *deployment.Spec.Replicas, int32(0))
+	assert.Equal(t, deployment.Annotations[InterceptReplicasAnnotation], "3")
+
+	// An intercept that didn't stop properly still restores the original replicas
+	replicas, err = scaleDownDeployment(kubeClient, namespace, "api")
+	assert.NilError(t, err, "Error scaling down deployment twice")
+	assert.Equal(t, replicas, int32(3))
+
+	err = restoreDeployment(kubeClient, namespace, "api", replicas)
+	assert.NilError(t, err, "Error restoring deployment")
+
+	deployment, _ = kubeClient.Client.AppsV1().Deployments(namespace).Get("api", metav1.GetOptions{})
+	assert.Equal(t, *deployment.Spec.Replicas, int32(3))
+	assert.Equal(t, deployment.Annotations[InterceptReplicasAnnotation], "")
+}
@@ -1,24 +1,84 @@
 package services
 
 import (
+	"bufio"
 	"context"
 	"io"
 	"os"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
 	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
-// StartLogs print the logs and then attaches to the container
-func StartLogs(config *latest.Config, client *kubectl.Client, cmdParameter targetselector.CmdParameter, follow bool, tail int64, log log.Logger) error {
-	return StartLogsWithWriter(config, client, cmdParameter, follow, tail, log, os.Stdout)
+// defaultLogsShowLast is the number of lines that are shown for each container before the log streaming starts
+const defaultLogsShowLast = 50
+
+// GetLogOptions returns the options for the multi-container log streaming defined in dev.logs. If neither images,
+// labelSelector nor deployments are configured, all containers that use images defined in the config are selected
+func GetLogOptions(config *latest.Config, generatedConfig *generated.Config) (*kubectl.LogOptions, error) {
+	logsConfig := &latest.LogsConfig{}
+	if config.Dev != nil && config.Dev.Logs != nil {
+		logsConfig = config.Dev.Logs
+	}
+
+	formatter, err := kubectl.NewLogFormatter(logsConfig.Include, logsConfig.Exclude, logsConfig.FormatJSON != nil && *logsConfig.FormatJSON)
+	if err != nil {
+		return nil, errors.Wrap(err, "dev.logs")
+	}
+
+	tail := int64(defaultLogsShowLast)
+	if logsConfig.ShowLast != nil {
+		tail = int64(*logsConfig.ShowLast)
+	}
+
+	options := &kubectl.LogOptions{
+		Deployments: logsConfig.Deployments,
+		Tail:        &tail,
+		Formatter:   formatter,
+	}
+	if len(logsConfig.LabelSelector) > 0 {
+		options.LabelSelector = labels.Set(logsConfig.LabelSelector).String()
+	}
+
+	// Build an image selector
+	imageNames := logsConfig.Images
+	if imageNames == nil && options.LabelSelector == "" && len(options.Deployments) == 0 {
+		imageNames = []string{}
+		for imageName := range config.Images {
+			imageNames = append(imageNames, imageName)
+		}
+	}
+
+	if generatedConfig != nil {
+		for _, imageName := range imageNames {
+			// Check that they are also in the generated config
+			if imageConfigCache, ok := generatedConfig.GetActive().Images[imageName]; ok && imageConfigCache.ImageName != "" {
+				options.ImageSelector = append(options.ImageSelector, imageConfigCache.ImageName+":"+imageConfigCache.Tag)
+			}
+		}
+	}
+
+	return options, nil
+}
+
+// StartLogs print the logs and then attaches to the container. If the formatter is not nil, the lines are filtered
+// and formatted by it
+func StartLogs(config *latest.Config, client *kubectl.Client, cmdParameter targetselector.CmdParameter, follow bool, tail int64, formatter *kubectl.LogFormatter, log log.Logger) error {
+	return startLogs(config, client, cmdParameter, follow, tail, formatter, log, os.Stdout)
 }
 
 // StartLogsWithWriter prints the logs and then attaches to the container with the given stdout and stderr
 func StartLogsWithWriter(config *latest.Config, client *kubectl.Client, cmdParameter targetselector.CmdParameter, follow bool, tail int64, log log.Logger, writer io.Writer) error {
+	return startLogs(config, client, cmdParameter, follow, tail, nil, log, writer)
+}
+
+func startLogs(config *latest.Config, client *kubectl.Client, cmdParameter targetselector.CmdParameter, follow bool, tail int64, formatter *kubectl.LogFormatter, log log.Logger, writer io.Writer) error {
 	selectorParameter := &targetselector.SelectorParameter{
 		CmdParameter: cmdParameter,
 	}
@@ -43,6 +103,21 @@ func StartLogsWithWriter(config *latest.Config, client *kubectl.Client, cmdParam
 		return nil
 	}
 
-	_, err = io.Copy(writer, reader)
-	return err
+	if formatter == nil {
+		_, err = io.Copy(writer, reader)
+		return err
+	}
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line, ok := formatter.Format(scanner.Text())
+		if ok {
+			_, err = writer.Write([]byte(line + "\n"))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return scanner.Err()
 }
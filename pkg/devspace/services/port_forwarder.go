@@ -0,0 +1,359 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
+	"github.com/devspace-cloud/devspace/pkg/util/hosts"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// healthCheckInterval is the interval in which the port forwarder checks if the pod was replaced
+var healthCheckInterval = time.Second * 5
+
+// reconnectMaxBackoff is the maximum time the port forwarder waits between two reconnect attempts
+var reconnectMaxBackoff = time.Second * 30
+
+// readyTimeout is the time to wait for the port forwarding to be ready
+var readyTimeout = time.Second * 20
+
+// PortForwarder forwards local ports to the newest pod that matches the target selector and ports of the pod back
+// to local ports. If the connection to the pod breaks or the pod is replaced, the port forwarder reconnects to the
+// newest pod on the same ports
+type PortForwarder struct {
+	client        *kubectl.Client
+	selector      *targetselector.TargetSelector
+	imageSelector []string
+	service       string
+	mappings      []*portMapping
+	reverse       []*reverseMapping
+	log           log.Logger
+
+	// hostsManager maps the cluster hostnames of the service to hostsIP while the port forwarder is running
+	hostsManager *hosts.Manager
+	hostsIP      string
+
+	conn    *forwardConnection
+	stopped bool
+	mutex   sync.Mutex
+
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+// forwardConnection is a single port forwarding connection to a pod, which consists of the port forwarding
+// and a reverse forwarding for every reverse mapping
+type forwardConnection struct {
+	pod *v1.Pod
+
+	stopChan chan struct{}
+	stopOnce sync.Once
+
+	// doneChan is closed as soon as one part of the connection ended
+	doneChan  chan struct{}
+	doneOnce  sync.Once
+	err       error
+	waitGroup sync.WaitGroup
+}
+
+// run runs a part of the connection in the background
+func (c *forwardConnection) run(fn func() error) {
+	c.waitGroup.Add(1)
+	go func() {
+		defer c.waitGroup.Done()
+
+		err := fn()
+		c.doneOnce.Do(func() {
+			c.err = err
+			close(c.doneChan)
+		})
+	}()
+}
+
+// stop stops all parts of the connection and waits until the local ports are released
+func (c *forwardConnection) stop() {
+	c.stopOnce.Do(func() {
+		close(c.stopChan)
+	})
+
+	c.waitGroup.Wait()
+}
+
+// newPortForwarder creates a new port forwarder and connects it to a pod
+func newPortForwarder(client *kubectl.Client, selector *targetselector.TargetSelector, imageSelector []string, service string, mappings []*portMapping, reverse []*reverseMapping, log log.Logger) (*PortForwarder, error) {
+	p := &PortForwarder{
+		client:        client,
+		selector:      selector,
+		imageSelector: imageSelector,
+		service:       service,
+		mappings:      mappings,
+		reverse:       reverse,
+		log:           log,
+		stopChan:      make(chan struct{}),
+	}
+
+	log.StartWait("Port-Forwarding: Waiting for containers to start...")
+	conn, err := p.connect()
+	log.StopWait()
+	if err != nil {
+		return nil, err
+	}
+
+	p.conn = conn
+	go p.watch()
+
+	return p, nil
+}
+
+// Close stops the port forwarding
+func (p *PortForwarder) Close() {
+	p.mutex.Lock()
+	p.stopped = true
+	conn := p.conn
+	p.mutex.Unlock()
+
+	p.stopOnce.Do(func() {
+		close(p.stopChan)
+	})
+
+	if conn != nil {
+		conn.stop()
+	}
+
+	if p.hostsManager != nil {
+		err := p.hostsManager.Remove(p.hostsIP)
+		if err != nil {
+			p.log.Warnf("Error removing hostnames of service %s: %v", p.service, err)
+		}
+
+		p.hostsManager = nil
+	}
+}
+
+func (p *PortForwarder) isStopped() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.stopped
+}
+
+// connect selects the newest pod and forwards the ports to it
+func (p *PortForwarder) connect() (*forwardConnection, error) {
+	p.selector.SkipWait = false
+
+	pod, err := p.selector.GetPod(log.Discard)
+	if err != nil {
+		return nil, errors.Errorf("Unable to list devspace pods: %s", err.Error())
+	} else if pod == nil {
+		return nil, errors.New("Unable to find a running pod")
+	}
+
+	conn := &forwardConnection{
+		pod:      pod,
+		stopChan: make(chan struct{}),
+		doneChan: make(chan struct{}),
+	}
+
+	readyChans := []chan struct{}{}
+	if len(p.mappings) > 0 {
+		ports, addresses, err := p.resolvePorts(pod)
+		if err != nil {
+			return nil, errors.Errorf("Error starting port forwarding: %v", err)
+		}
+
+		readyChan := make(chan struct{})
+		pf, err := p.client.NewPortForwarder(pod, ports, addresses, conn.stopChan, readyChan)
+		if err != nil {
+			return nil, errors.Errorf("Error starting port forwarding: %v", err)
+		}
+
+		conn.run(pf.ForwardPorts)
+		readyChans = append(readyChans, readyChan)
+	}
+
+	if len(p.reverse) > 0 {
+		container := getReverseContainer(pod, p.imageSelector)
+		err = injectSync(p.client, pod, container)
+		if err != nil {
+			conn.stop()
+			return nil, errors.Errorf("Error starting reverse port forwarding: %v", err)
+		}
+
+		for _, mapping := range p.reverse {
+			readyChan := make(chan struct{})
+			mapping := mapping
+
+			conn.run(func() error {
+				return reverseForward(p.client, pod, container, mapping, readyChan, conn.stopChan)
+			})
+			readyChans = append(readyChans, readyChan)
+		}
+	}
+
+	// Wait till forwarding is ready
+	timeout := time.After(readyTimeout)
+	for _, readyChan := range readyChans {
+		select {
+		case <-readyChan:
+		case <-conn.doneChan:
+			conn.stop()
+			return nil, errors.Errorf("Error forwarding ports: %v", conn.err)
+		case <-timeout:
+			conn.stop()
+			return nil, errors.Errorf("Timeout waiting for port forwarding to start")
+		}
+	}
+
+	return conn, nil
+}
+
+// watch waits for the connection to break or the pod to be replaced and reconnects
+func (p *PortForwarder) watch() {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		p.mutex.Lock()
+		conn := p.conn
+		p.mutex.Unlock()
+
+		select {
+		case <-p.stopChan:
+			return
+		case <-conn.doneChan:
+			if p.isStopped() {
+				return
+			}
+
+			if conn.err != nil {
+				p.log.Warnf("Port-Forwarding: Lost connection to pod %s: %v. Reconnecting...", conn.pod.Name, conn.err)
+			} else {
+				p.log.Warnf("Port-Forwarding: Lost connection to pod %s. Reconnecting...", conn.pod.Name)
+			}
+
+			conn.stop()
+		case <-ticker.C:
+			reason := p.checkPod(conn.pod)
+			if reason == "" {
+				continue
+			}
+
+			p.log.Infof("Port-Forwarding: %s. Reconnecting...", reason)
+			conn.stop()
+		}
+
+		if p.reconnect() == false {
+			return
+		}
+	}
+}
+
+// checkPod returns the reason why the port forwarder should reconnect or an empty string if the pod is still fine
+func (p *PortForwarder) checkPod(pod *v1.Pod) string {
+	p.selector.SkipWait = true
+
+	newest, err := p.selector.GetPod(log.Discard)
+	if err == nil && newest != nil && newest.Name != pod.Name && newest.CreationTimestamp.After(pod.CreationTimestamp.Time) {
+		return fmt.Sprintf("Pod %s was replaced by %s", pod.Name, newest.Name)
+	}
+
+	current, err := p.client.Client.CoreV1().Pods(pod.Namespace).Get(pod.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return fmt.Sprintf("Pod %s was deleted", pod.Name)
+		}
+
+		// The connection might still be fine, so we try again later
+		return ""
+	}
+
+	if current.DeletionTimestamp != nil {
+		return fmt.Sprintf("Pod %s is terminating", pod.Name)
+	} else if status := kubectl.GetPodStatus(current); status != "Running" {
+		return fmt.Sprintf("Pod %s has status %s", pod.Name, status)
+	}
+
+	return ""
+}
+
+// resolvePorts returns the ports and addresses for the port forwarding to the pod. Named ports are resolved
+// every time, because the port numbers might change when the pod is replaced
+func (p *PortForwarder) resolvePorts(pod *v1.Pod) ([]string, []string, error) {
+	var service *v1.Service
+	if p.service != "" {
+		var err error
+		service, err = getService(p.client, pod.Namespace, p.service)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	ports := make([]string, 0, len(p.mappings))
+	addresses := make([]string, 0, len(p.mappings))
+	for _, mapping := range p.mappings {
+		remotePort, err := resolveRemotePort(mapping, pod, service)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ports = append(ports, mapping.localPort+":"+remotePort)
+		addresses = append(addresses, mapping.address)
+	}
+
+	return ports, addresses, nil
+}
+
+// String returns the forwarded ports, e.g. 8080:http, 0.0.0.0:9229 -> 127.0.0.1:9229
+func (p *PortForwarder) String() string {
+	ports := []string{}
+	for _, mapping := range p.mappings {
+		ports = append(ports, mapping.String())
+	}
+	for _, mapping := range p.reverse {
+		ports = append(ports, mapping.String())
+	}
+
+	return strings.Join(ports, ", ")
+}
+
+// reconnect connects to the newest pod until it succeeds or the port forwarder is closed
+func (p *PortForwarder) reconnect() bool {
+	backoff := time.Second
+	for {
+		conn, err := p.connect()
+		if err == nil {
+			p.mutex.Lock()
+			if p.stopped {
+				p.mutex.Unlock()
+				conn.stop()
+				return false
+			}
+
+			p.conn = conn
+			p.mutex.Unlock()
+
+			p.log.Donef("Port forwarding reconnected to pod %s on %s", conn.pod.Name, p.String())
+			return true
+		}
+
+		p.log.Warnf("Port-Forwarding: Error reconnecting: %v. Retrying in %s", err, backoff)
+		select {
+		case <-p.stopChan:
+			return false
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > reconnectMaxBackoff {
+			backoff = reconnectMaxBackoff
+		}
+	}
+}
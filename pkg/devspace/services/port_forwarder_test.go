@@ -0,0 +1,94 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"gotest.tools/assert"
+)
+
+type checkPodTestCase struct {
+	name string
+
+	pods []string
+	pod  string
+
+	expectedReason string
+}
+
+func TestCheckPod(t *testing.T) {
+	namespace := "test"
+	now := time.Now()
+
+	testCases := []checkPodTestCase{
+		checkPodTestCase{
+			name: "Pod is fine",
+			pods: []string{"pod-1"},
+			pod:  "pod-1",
+		},
+		checkPodTestCase{
+			name:           "Pod was replaced",
+			pods:           []string{"pod-1", "pod-2"},
+			pod:            "pod-1",
+			expectedReason: "Pod pod-1 was replaced by pod-2",
+		},
+		checkPodTestCase{
+			name:           "Pod was deleted",
+			pods:           []string{},
+			pod:            "pod-1",
+			expectedReason: "Pod pod-1 was deleted",
+		},
+	}
+
+	for _, testCase := range testCases {
+		kubeClient := &kubectl.Client{
+			Client:    fake.NewSimpleClientset(),
+			Namespace: namespace,
+		}
+
+		for idx, name := range testCase.pods {
+			_, err := kubeClient.Client.CoreV1().Pods(namespace).Create(&k8sv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					Namespace:         namespace,
+					Labels:            map[string]string{"app": "test"},
+					CreationTimestamp: metav1.NewTime(now.Add(time.Duration(idx) * time.Minute)),
+				},
+				Status: k8sv1.PodStatus{
+					Phase: k8sv1.PodRunning,
+				},
+			})
+			assert.NilError(t, err, "Error creating pod in testCase %s", testCase.name)
+		}
+
+		selector, err := targetselector.NewTargetSelector(&latest.Config{}, kubeClient, &targetselector.SelectorParameter{
+			ConfigParameter: targetselector.ConfigParameter{
+				Namespace:     namespace,
+				LabelSelector: map[string]string{"app": "test"},
+			},
+		}, false, nil)
+		assert.NilError(t, err, "Error creating target selector in testCase %s", testCase.name)
+
+		portForwarder := &PortForwarder{
+			client:   kubeClient,
+			selector: selector,
+		}
+
+		reason := portForwarder.checkPod(&k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              testCase.pod,
+				Namespace:         namespace,
+				CreationTimestamp: metav1.NewTime(now),
+			},
+		})
+		assert.Equal(t, reason, testCase.expectedReason, "Unexpected reason in testCase %s", testCase.name)
+	}
+}
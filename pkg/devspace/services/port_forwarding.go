@@ -3,23 +3,25 @@ package services
 import (
 	"strconv"
 	"strings"
-	"time"
-
-	"k8s.io/client-go/tools/portforward"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
 	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
+	"github.com/devspace-cloud/devspace/pkg/util/hosts"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/devspace-cloud/devspace/pkg/util/port"
 	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // StartPortForwarding starts the port forwarding functionality
-func StartPortForwarding(config *latest.Config, generatedConfig *generated.Config, client *kubectl.Client, log log.Logger) ([]*portforward.PortForwarder, error) {
+func StartPortForwarding(config *latest.Config, generatedConfig *generated.Config, client *kubectl.Client, log log.Logger) ([]*PortForwarder, error) {
 	if config.Dev.Ports != nil {
-		portforwarder := make([]*portforward.PortForwarder, 0, len(config.Dev.Ports))
+		portforwarder := make([]*PortForwarder, 0, len(config.Dev.Ports))
+		hostsManager := getHostsManager(config)
 
 		for portConfigIndex, portForwarding := range config.Dev.Ports {
 			var imageSelector []string
@@ -30,73 +32,106 @@ func StartPortForwarding(config *latest.Config, generatedConfig *generated.Confi
 				}
 			}
 
+			// Services select the pods with their selector
+			namespace := portForwarding.Namespace
+			labelSelector := portForwarding.LabelSelector
+			hostsIP := ""
+			if portForwarding.Service != "" {
+				service, err := getService(client, namespace, portForwarding.Service)
+				if err != nil {
+					closePortForwarder(portforwarder)
+					return nil, errors.Errorf("Error starting port-forwarding: %v", err)
+				}
+
+				namespace = service.Namespace
+				labelSelector = service.Spec.Selector
+
+				// Bind the service to its own loopback ip, which is reachable with the cluster hostnames of the service
+				if hostsManager != nil {
+					hostsIP = hosts.LoopbackIP(service.Name + "." + service.Namespace)
+					err = hostsManager.Add(hostsIP, hosts.ServiceHostnames(service.Name, service.Namespace))
+					if err != nil {
+						closePortForwarder(portforwarder)
+						return nil, errors.Errorf("Error mapping hostnames of service %s: %v. Please make sure you are allowed to edit the hosts file or disable dev.hosts", service.Name, err)
+					}
+				}
+			}
+
+			// removeHosts removes the hostnames again if the port forwarding cannot be started
+			removeHosts := func() {
+				if hostsIP != "" {
+					hostsManager.Remove(hostsIP)
+				}
+			}
+
 			selector, err := targetselector.NewTargetSelector(config, client, &targetselector.SelectorParameter{
 				ConfigParameter: targetselector.ConfigParameter{
-					Namespace:     portForwarding.Namespace,
-					LabelSelector: portForwarding.LabelSelector,
+					Namespace:     namespace,
+					LabelSelector: labelSelector,
 				},
 			}, false, imageSelector)
 			if err != nil {
+				removeHosts()
+				closePortForwarder(portforwarder)
 				return nil, errors.Errorf("Error creating target selector: %v", err)
 			}
 
-			log.StartWait("Port-Forwarding: Waiting for containers to start...")
-			pod, err := selector.GetPod(log)
-			log.StopWait()
-			if err != nil {
-				return nil, errors.Errorf("Error starting port-forwarding: Unable to list devspace pods: %s", err.Error())
-			} else if pod != nil {
-				ports := make([]string, len(portForwarding.PortMappings))
-				addresses := make([]string, len(portForwarding.PortMappings))
-
-				for index, value := range portForwarding.PortMappings {
-					if value.LocalPort == nil {
-						return nil, errors.Errorf("port is not defined in portmapping %d:%d", portConfigIndex, index)
-					}
-
-					localPort := strconv.Itoa(*value.LocalPort)
-					remotePort := localPort
-					if value.RemotePort != nil {
-						remotePort = strconv.Itoa(*value.RemotePort)
-					}
+			mappings := make([]*portMapping, 0, len(portForwarding.PortMappings))
+			for index, value := range portForwarding.PortMappings {
+				if value.LocalPort == nil {
+					removeHosts()
+					closePortForwarder(portforwarder)
+					return nil, errors.Errorf("port is not defined in portmapping %d:%d", portConfigIndex, index)
+				}
 
-					open, _ := port.Check(*value.LocalPort)
-					if open == false {
-						log.Warnf("Seems like port %d is already in use. Is another application using that port?", *value.LocalPort)
-					}
+				mapping := &portMapping{
+					localPort:  strconv.Itoa(*value.LocalPort),
+					remotePort: strconv.Itoa(*value.LocalPort),
+					address:    value.BindAddress,
+				}
+				if value.RemotePort != nil {
+					mapping.remotePort = strings.TrimSpace(*value.RemotePort)
+					mapping.explicit = true
+				}
+				if mapping.address == "" && hostsIP != "" {
+					mapping.address = hostsIP
+				} else if mapping.address == "" {
+					mapping.address = "127.0.0.1"
+				}
 
-					ports[index] = localPort + ":" + remotePort
-					if value.BindAddress == "" {
-						addresses[index] = "127.0.0.1"
-					} else {
-						addresses[index] = value.BindAddress
-					}
+				open, _ := port.CheckHostPort(mapping.address, *value.LocalPort)
+				if open == false {
+					log.Warnf("Seems like port %d is already in use. Is another application using that port?", *value.LocalPort)
 				}
 
-				readyChan := make(chan struct{})
+				mappings = append(mappings, mapping)
+			}
 
-				pf, err := client.NewPortForwarder(pod, ports, addresses, make(chan struct{}), readyChan)
-				if err != nil {
-					return nil, errors.Errorf("Error starting port forwarding: %v", err)
-				}
+			reverse, err := getReverseMappings(portConfigIndex, portForwarding.ReverseForward)
+			if err != nil {
+				removeHosts()
+				closePortForwarder(portforwarder)
+				return nil, err
+			} else if len(mappings) == 0 && len(reverse) == 0 {
+				removeHosts()
+				continue
+			}
 
-				go func() {
-					err := pf.ForwardPorts()
-					if err != nil {
-						log.Fatalf("Error forwarding ports: %v", err)
-					}
-				}()
+			pf, err := newPortForwarder(client, selector, imageSelector, portForwarding.Service, mappings, reverse, log)
+			if err != nil {
+				removeHosts()
+				closePortForwarder(portforwarder)
+				return nil, errors.Errorf("Error starting port-forwarding: %v", err)
+			}
 
-				// Wait till forwarding is ready
-				select {
-				case <-readyChan:
-					log.Donef("Port forwarding started on %s", strings.Join(ports, ", "))
+			log.Donef("Port forwarding started on %s", pf.String())
+			if hostsIP != "" {
+				pf.hostsManager = hostsManager
+				pf.hostsIP = hostsIP
 
-					portforwarder = append(portforwarder, pf)
-				case <-time.After(20 * time.Second):
-					return nil, errors.Errorf("Timeout waiting for port forwarding to start")
-				}
+				log.Infof("Service %s is reachable as %s.%s.svc.cluster.local (%s)", portForwarding.Service, portForwarding.Service, namespace, hostsIP)
 			}
+			portforwarder = append(portforwarder, pf)
 		}
 
 		return portforwarder, nil
@@ -104,3 +139,149 @@ func StartPortForwarding(config *latest.Config, generatedConfig *generated.Confi
 
 	return nil, nil
 }
+
+// getHostsManager returns the hosts manager if forwarded services should be reachable with their cluster hostnames
+func getHostsManager(config *latest.Config) *hosts.Manager {
+	if config.Dev.Hosts == nil || config.Dev.Hosts.Enabled == nil || *config.Dev.Hosts.Enabled == false {
+		return nil
+	}
+
+	hostsFile := config.Dev.Hosts.HostsFile
+	if hostsFile == "" {
+		hostsFile = hosts.DefaultPath()
+	}
+
+	return hosts.GetManager(hostsFile)
+}
+
+func closePortForwarder(portforwarder []*PortForwarder) {
+	for _, pf := range portforwarder {
+		pf.Close()
+	}
+}
+
+// portMapping forwards a local port to a remote port, which is either a port number or the name of a container port.
+// If the pods are selected by a service, the remote port is a port number or the name of a port of the service
+type portMapping struct {
+	localPort  string
+	remotePort string
+	address    string
+
+	// explicit is true if the remote port was configured and not defaulted to the local port
+	explicit bool
+}
+
+func (m *portMapping) String() string {
+	return m.localPort + ":" + m.remotePort
+}
+
+func getService(client *kubectl.Client, namespace, name string) (*v1.Service, error) {
+	if namespace == "" {
+		namespace = client.Namespace
+	}
+
+	service, err := client.Client.CoreV1().Services(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "get service %s", name)
+	} else if len(service.Spec.Selector) == 0 {
+		return nil, errors.Errorf("Service %s has no selector", name)
+	}
+
+	return service, nil
+}
+
+// resolveRemotePort returns the container port number the mapping forwards to in the given pod
+func resolveRemotePort(mapping *portMapping, pod *v1.Pod, service *v1.Service) (string, error) {
+	remotePort := mapping.remotePort
+	if service != nil {
+		servicePort, err := findServicePort(service, remotePort, mapping.explicit)
+		if err != nil {
+			return "", err
+		}
+
+		if servicePort.TargetPort.Type == intstr.String {
+			remotePort = servicePort.TargetPort.StrVal
+		} else if servicePort.TargetPort.IntVal != 0 {
+			remotePort = strconv.Itoa(int(servicePort.TargetPort.IntVal))
+		} else {
+			remotePort = strconv.Itoa(int(servicePort.Port))
+		}
+	}
+
+	if _, err := strconv.Atoi(remotePort); err == nil {
+		return remotePort, nil
+	}
+
+	// Find the named container port
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == remotePort {
+				return strconv.Itoa(int(containerPort.ContainerPort)), nil
+			}
+		}
+	}
+
+	return "", errors.Errorf("Couldn't find a container port named %s in pod %s", remotePort, pod.Name)
+}
+
+// findServicePort finds the port of the service by its name or number. If the remote port was not configured and
+// the service has only a single port, this port is used
+func findServicePort(service *v1.Service, remotePort string, explicit bool) (*v1.ServicePort, error) {
+	for idx, servicePort := range service.Spec.Ports {
+		if servicePort.Name == remotePort || strconv.Itoa(int(servicePort.Port)) == remotePort {
+			return &service.Spec.Ports[idx], nil
+		}
+	}
+
+	if explicit == false && len(service.Spec.Ports) == 1 {
+		return &service.Spec.Ports[0], nil
+	}
+
+	return nil, errors.Errorf("Service %s has no port %s", service.Name, remotePort)
+}
+
+// CheckPortConflicts makes sure that no local port is forwarded more than once by the given configs.
+// The names are used to tell the user where the conflicting port forwardings are defined
+func CheckPortConflicts(names []string, configs []*latest.Config) error {
+	type usedPort struct {
+		name        string
+		bindAddress string
+	}
+
+	usedPorts := map[int][]usedPort{}
+	for idx, config := range configs {
+		if config == nil || config.Dev == nil {
+			continue
+		}
+
+		for _, portForwarding := range config.Dev.Ports {
+			for _, value := range portForwarding.PortMappings {
+				if value.LocalPort == nil {
+					continue
+				}
+
+				bindAddress := value.BindAddress
+				if bindAddress == "" && portForwarding.Service != "" && getHostsManager(config) != nil {
+					// Every service is bound to its own loopback ip
+					bindAddress = "service " + portForwarding.Service + "." + portForwarding.Namespace
+				} else if bindAddress == "" {
+					bindAddress = "127.0.0.1"
+				}
+
+				for _, used := range usedPorts[*value.LocalPort] {
+					if used.bindAddress == bindAddress || used.bindAddress == "0.0.0.0" || bindAddress == "0.0.0.0" {
+						if used.name == names[idx] {
+							return errors.Errorf("Local port %d is forwarded more than once in %s", *value.LocalPort, names[idx])
+						}
+
+						return errors.Errorf("Local port %d is forwarded by %s and %s. Please change the localPort in one of them", *value.LocalPort, used.name, names[idx])
+					}
+				}
+
+				usedPorts[*value.LocalPort] = append(usedPorts[*value.LocalPort], usedPort{name: names[idx], bindAddress: bindAddress})
+			}
+		}
+	}
+
+	return nil
+}
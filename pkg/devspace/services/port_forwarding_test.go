@@ -5,6 +5,10 @@ import (
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"gotest.tools/assert"
 )
@@ -30,3 +34,180 @@ func TestStartPortForwarding(t *testing.T) {
 	}
 	assert.Equal(t, 0, len(portForwarder), "Ports forwarded despite 0 ports given to forward.")
 }
+
+type checkPortConflictsTestCase struct {
+	name string
+
+	names   []string
+	configs []*latest.Config
+
+	expectedErr string
+}
+
+func TestCheckPortConflicts(t *testing.T) {
+	portConfig := func(bindAddress string, ports ...int) *latest.Config {
+		portMappings := []*latest.PortMapping{}
+		for _, port := range ports {
+			portMappings = append(portMappings, &latest.PortMapping{LocalPort: ptr.Int(port), BindAddress: bindAddress})
+		}
+
+		return &latest.Config{
+			Dev: &latest.DevConfig{
+				Ports: []*latest.PortForwardingConfig{
+					&latest.PortForwardingConfig{PortMappings: portMappings},
+				},
+			},
+		}
+	}
+
+	testCases := []checkPortConflictsTestCase{
+		checkPortConflictsTestCase{
+			name:    "No conflicts",
+			names:   []string{"root", "dep", "empty"},
+			configs: []*latest.Config{portConfig("", 8080), portConfig("", 8081), &latest.Config{}},
+		},
+		checkPortConflictsTestCase{
+			name:    "Different bind addresses",
+			names:   []string{"root", "dep"},
+			configs: []*latest.Config{portConfig("", 8080), portConfig("127.0.0.2", 8080)},
+		},
+		checkPortConflictsTestCase{
+			name:        "Conflict between configs",
+			names:       []string{"root", "dep"},
+			configs:     []*latest.Config{portConfig("", 8080), portConfig("", 8080)},
+			expectedErr: "Local port 8080 is forwarded by root and dep. Please change the localPort in one of them",
+		},
+		checkPortConflictsTestCase{
+			name:        "Conflict with all interfaces",
+			names:       []string{"root", "dep"},
+			configs:     []*latest.Config{portConfig("0.0.0.0", 8080), portConfig("127.0.0.2", 8080)},
+			expectedErr: "Local port 8080 is forwarded by root and dep. Please change the localPort in one of them",
+		},
+		checkPortConflictsTestCase{
+			name:        "Conflict in one config",
+			names:       []string{"root"},
+			configs:     []*latest.Config{portConfig("", 8080, 8080)},
+			expectedErr: "Local port 8080 is forwarded more than once in root",
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := CheckPortConflicts(testCase.names, testCase.configs)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+		}
+	}
+}
+
+type resolveRemotePortTestCase struct {
+	name string
+
+	mapping *portMapping
+	service *k8sv1.Service
+
+	expectedPort string
+	expectedErr  string
+}
+
+func TestResolveRemotePort(t *testing.T) {
+	pod := &k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pod-1",
+		},
+		Spec: k8sv1.PodSpec{
+			Containers: []k8sv1.Container{
+				k8sv1.Container{
+					Name: "container-1",
+					Ports: []k8sv1.ContainerPort{
+						k8sv1.ContainerPort{
+							Name:          "http",
+							ContainerPort: 8080,
+						},
+					},
+				},
+			},
+		},
+	}
+	service := &k8sv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "service-1",
+		},
+		Spec: k8sv1.ServiceSpec{
+			Ports: []k8sv1.ServicePort{
+				k8sv1.ServicePort{
+					Name:       "web",
+					Port:       80,
+					TargetPort: intstr.FromString("http"),
+				},
+				k8sv1.ServicePort{
+					Name:       "metrics",
+					Port:       9090,
+					TargetPort: intstr.FromInt(9091),
+				},
+			},
+		},
+	}
+
+	testCases := []resolveRemotePortTestCase{
+		resolveRemotePortTestCase{
+			name:         "Port number",
+			mapping:      &portMapping{localPort: "3000", remotePort: "3000"},
+			expectedPort: "3000",
+		},
+		resolveRemotePortTestCase{
+			name:         "Named container port",
+			mapping:      &portMapping{localPort: "3000", remotePort: "http", explicit: true},
+			expectedPort: "8080",
+		},
+		resolveRemotePortTestCase{
+			name:        "Unknown container port",
+			mapping:     &portMapping{localPort: "3000", remotePort: "grpc", explicit: true},
+			expectedErr: "Couldn't find a container port named grpc in pod pod-1",
+		},
+		resolveRemotePortTestCase{
+			name:         "Service port with named target port",
+			mapping:      &portMapping{localPort: "3000", remotePort: "80", explicit: true},
+			service:      service,
+			expectedPort: "8080",
+		},
+		resolveRemotePortTestCase{
+			name:         "Named service port",
+			mapping:      &portMapping{localPort: "3000", remotePort: "metrics", explicit: true},
+			service:      service,
+			expectedPort: "9091",
+		},
+		resolveRemotePortTestCase{
+			name:        "Unknown service port",
+			mapping:     &portMapping{localPort: "3000", remotePort: "3000"},
+			service:     service,
+			expectedErr: "Service service-1 has no port 3000",
+		},
+		resolveRemotePortTestCase{
+			name:    "Single service port",
+			mapping: &portMapping{localPort: "3000", remotePort: "3000"},
+			service: &k8sv1.Service{
+				Spec: k8sv1.ServiceSpec{
+					Ports: []k8sv1.ServicePort{
+						k8sv1.ServicePort{
+							Port: 80,
+						},
+					},
+				},
+			},
+			expectedPort: "80",
+		},
+	}
+
+	for _, testCase := range testCases {
+		port, err := resolveRemotePort(testCase.mapping, pod, testCase.service)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+		}
+
+		assert.Equal(t, port, testCase.expectedPort, "Wrong port in testCase %s", testCase.name)
+	}
+}
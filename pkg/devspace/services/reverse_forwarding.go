@@ -0,0 +1,131 @@
+package services
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/sync/tunnel"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+)
+
+// reverseStopTimeout is the time to wait for the helper in the container to exit after the tunnel was closed
+var reverseStopTimeout = time.Second * 5
+
+// reverseMapping tunnels connections to an address in the container to a local address
+type reverseMapping struct {
+	localAddress  string
+	remoteAddress string
+}
+
+// getReverseMappings converts the reverseForward config into reverse mappings. The port is the local port, the
+// remotePort the port the helper listens on in the container and the bindAddress the address it binds to
+func getReverseMappings(portConfigIndex int, portMappings []*latest.PortMapping) ([]*reverseMapping, error) {
+	mappings := make([]*reverseMapping, 0, len(portMappings))
+	for index, value := range portMappings {
+		if value.LocalPort == nil {
+			return nil, errors.Errorf("port is not defined in reverseForward %d:%d", portConfigIndex, index)
+		}
+
+		localPort := strconv.Itoa(*value.LocalPort)
+		remotePort := localPort
+		if value.RemotePort != nil {
+			remotePort = strings.TrimSpace(*value.RemotePort)
+			if _, err := strconv.Atoi(remotePort); err != nil {
+				return nil, errors.Errorf("remotePort %s in reverseForward %d:%d has to be a port number", remotePort, portConfigIndex, index)
+			}
+		}
+
+		bindAddress := value.BindAddress
+		if bindAddress == "" {
+			bindAddress = "0.0.0.0"
+		}
+
+		mappings = append(mappings, &reverseMapping{
+			localAddress:  net.JoinHostPort("127.0.0.1", localPort),
+			remoteAddress: net.JoinHostPort(bindAddress, remotePort),
+		})
+	}
+
+	return mappings, nil
+}
+
+func (m *reverseMapping) String() string {
+	return m.remoteAddress + " -> " + m.localAddress
+}
+
+// getReverseContainer returns the container the helper is injected into. All containers of a pod share the same
+// network, so the container that uses one of the selected images is preferred, otherwise the first one is used
+func getReverseContainer(pod *v1.Pod, imageSelector []string) string {
+	for _, container := range pod.Spec.Containers {
+		for _, imageName := range imageSelector {
+			if imageName == container.Image {
+				return container.Name
+			}
+		}
+	}
+
+	return pod.Spec.Containers[0].Name
+}
+
+// reverseForward starts the helper in the container, which listens on the remote address, and opens a connection
+// to the local address for every connection the helper accepts. It returns when the stop channel is closed or
+// the connection to the container breaks
+func reverseForward(client *kubectl.Client, pod *v1.Pod, container string, mapping *reverseMapping, readyChan, stopChan chan struct{}) error {
+	stdinReader, stdinWriter := io.Pipe()
+	stdoutReader, stdoutWriter := io.Pipe()
+
+	var (
+		execDone = make(chan struct{})
+		execErr  error
+	)
+	go func() {
+		stderrBuffer := &bytes.Buffer{}
+
+		execErr = client.ExecStream(pod, container, []string{SyncHelperContainerPath, "--reverse-forward", mapping.remoteAddress}, false, stdinReader, stdoutWriter, stderrBuffer)
+		if execErr != nil && stderrBuffer.Len() > 0 {
+			execErr = errors.Errorf("%v: %s", execErr, strings.TrimSpace(stderrBuffer.String()))
+		}
+
+		stdoutWriter.Close()
+		close(execDone)
+	}()
+
+	// Closing stdin stops the helper in the container
+	go func() {
+		select {
+		case <-stopChan:
+		case <-execDone:
+			return
+		}
+
+		stdinWriter.Close()
+		select {
+		case <-execDone:
+		case <-time.After(reverseStopTimeout):
+			stdoutWriter.Close()
+		}
+	}()
+
+	err := tunnel.Dial(func() (net.Conn, error) {
+		return net.Dial("tcp", mapping.localAddress)
+	}, stdoutReader, stdinWriter, readyChan)
+	stdinWriter.Close()
+
+	select {
+	case <-stopChan:
+		return nil
+	case <-execDone:
+		if execErr != nil {
+			return errors.Wrapf(execErr, "reverse forward %s", mapping.String())
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	"gotest.tools/assert"
+)
+
+func TestGetReverseMappings(t *testing.T) {
+	mappings, err := getReverseMappings(0, []*latest.PortMapping{
+		&latest.PortMapping{LocalPort: ptr.Int(9229)},
+		&latest.PortMapping{LocalPort: ptr.Int(8080), RemotePort: ptr.String("80"), BindAddress: "127.0.0.1"},
+	})
+	assert.NilError(t, err, "Error getting reverse mappings")
+	assert.Equal(t, len(mappings), 2)
+	assert.Equal(t, mappings[0].String(), "0.0.0.0:9229 -> 127.0.0.1:9229")
+	assert.Equal(t, mappings[1].String(), "127.0.0.1:80 -> 127.0.0.1:8080")
+
+	_, err = getReverseMappings(1, []*latest.PortMapping{&latest.PortMapping{}})
+	assert.Error(t, err, "port is not defined in reverseForward 1:0")
+}
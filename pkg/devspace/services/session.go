@@ -0,0 +1,190 @@
+package services
+
+import (
+	"os"
+	"sort"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+	kubectlExec "k8s.io/client-go/util/exec"
+)
+
+// sessionReconnectAttempts is the number of times the terminal reconnects to a session after the connection broke
+var sessionReconnectAttempts = 10
+
+// sessionReconnectInterval is the time to wait before reconnecting to a session
+var sessionReconnectInterval = time.Second * 2
+
+// GetTerminalSession returns the terminal session with the given name from dev.terminals or nil if there is none
+func GetTerminalSession(config *latest.Config, name string) *latest.TerminalSessionConfig {
+	if config == nil || config.Dev == nil {
+		return nil
+	}
+
+	for _, terminal := range config.Dev.Terminals {
+		if terminal.Name == name {
+			return terminal
+		}
+	}
+
+	return nil
+}
+
+// StartSessions starts all sessions of dev.terminals in the background without attaching to them
+func StartSessions(config *latest.Config, generatedConfig *generated.Config, client *kubectl.Client, log log.Logger) {
+	for _, terminal := range config.Dev.Terminals {
+		go func(terminal *latest.TerminalSessionConfig) {
+			err := startSession(config, generatedConfig, client, terminal)
+			if err != nil {
+				log.Warnf("Error starting terminal session %s: %v", terminal.Name, err)
+				return
+			}
+
+			log.Donef("Started terminal session %s. Run `devspace enter --session %s` to attach to it", terminal.Name, terminal.Name)
+		}(terminal)
+	}
+}
+
+func startSession(config *latest.Config, generatedConfig *generated.Config, client *kubectl.Client, terminal *latest.TerminalSessionConfig) error {
+	targetSelector, err := targetselector.NewTargetSelector(config, client, getSessionSelectorParameter(terminal, nil), false, getSessionImageSelector(generatedConfig, terminal))
+	if err != nil {
+		return err
+	}
+
+	pod, container, err := targetSelector.GetContainer(false, log.Discard)
+	if err != nil {
+		return err
+	}
+
+	err = injectSync(client, pod, container.Name)
+	if err != nil {
+		return errors.Wrap(err, "inject helper")
+	}
+
+	_, stderr, err := client.ExecBuffered(pod, container.Name, getSessionCommand(terminal, true), nil)
+	if err != nil {
+		if len(stderr) > 0 {
+			return errors.Errorf("%v: %s", err, string(stderr))
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// StartSessionTerminal attaches the terminal to the persistent session in the container. The session is started
+// if it does not exist yet. If the connection breaks, the terminal reconnects to the session
+func StartSessionTerminal(config *latest.Config, generatedConfig *generated.Config, client *kubectl.Client, terminal *latest.TerminalSessionConfig, cmdParameter *targetselector.CmdParameter, log log.Logger) (int, error) {
+	targetSelector, err := targetselector.NewTargetSelector(config, client, getSessionSelectorParameter(terminal, cmdParameter), true, getSessionImageSelector(generatedConfig, terminal))
+	if err != nil {
+		return 0, err
+	}
+
+	targetSelector.PodQuestion = ptr.String("Which pod do you want to open the session in?")
+
+	for attempt := 0; ; attempt++ {
+		pod, container, err := targetSelector.GetContainer(true, log)
+		if err != nil {
+			return 0, err
+		}
+
+		err = injectSync(client, pod, container.Name)
+		if err != nil {
+			return 0, errors.Wrap(err, "inject helper")
+		}
+
+		wrapper, upgradeRoundTripper, err := kubectl.GetUpgraderWrapper(client.RestConfig)
+		if err != nil {
+			return 0, err
+		}
+
+		log.WriteString("\n")
+		log.Infof("Attaching to session %s in pod:container %s:%s (detach with Ctrl+P Ctrl+Q)", ansi.Color(terminal.Name, "white+b"), ansi.Color(pod.Name, "white+b"), ansi.Color(container.Name, "white+b"))
+		log.WriteString("\n")
+
+		err = client.ExecStreamWithTransport(wrapper, upgradeRoundTripper, pod, container.Name, getSessionCommand(terminal, false), true, os.Stdin, os.Stdout, os.Stderr, kubectl.SubResourceExec)
+		upgradeRoundTripper.Close()
+		if err == nil {
+			return 0, nil
+		} else if exitError, ok := err.(kubectlExec.CodeExitError); ok {
+			return exitError.Code, nil
+		} else if attempt >= sessionReconnectAttempts {
+			return 0, errors.Wrapf(err, "attach to session %s", terminal.Name)
+		}
+
+		log.Warnf("Lost connection to session %s: %v. Reconnecting...", terminal.Name, err)
+		time.Sleep(sessionReconnectInterval)
+
+		// Reconnect to the same container, because the session only exists there
+		targetSelector, err = targetselector.NewTargetSelector(config, client, &targetselector.SelectorParameter{
+			CmdParameter: targetselector.CmdParameter{
+				Namespace:     pod.Namespace,
+				PodName:       pod.Name,
+				ContainerName: container.Name,
+			},
+		}, false, nil)
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+func getSessionSelectorParameter(terminal *latest.TerminalSessionConfig, cmdParameter *targetselector.CmdParameter) *targetselector.SelectorParameter {
+	selectorParameter := &targetselector.SelectorParameter{
+		ConfigParameter: targetselector.ConfigParameter{
+			Namespace:     terminal.Namespace,
+			LabelSelector: terminal.LabelSelector,
+			ContainerName: terminal.ContainerName,
+		},
+	}
+	if cmdParameter != nil {
+		selectorParameter.CmdParameter = *cmdParameter
+	}
+
+	return selectorParameter
+}
+
+func getSessionImageSelector(generatedConfig *generated.Config, terminal *latest.TerminalSessionConfig) []string {
+	if terminal.ImageName == "" || generatedConfig == nil {
+		return nil
+	}
+
+	imageConfigCache := generatedConfig.GetActive().GetImageCache(terminal.ImageName)
+	if imageConfigCache.ImageName == "" {
+		return nil
+	}
+
+	return []string{imageConfigCache.ImageName + ":" + imageConfigCache.Tag}
+}
+
+// getSessionCommand returns the command that starts the helper for the session in the container
+func getSessionCommand(terminal *latest.TerminalSessionConfig, detach bool) []string {
+	command := []string{SyncHelperContainerPath, "--session", terminal.Name}
+	if detach {
+		command = append(command, "--detach")
+	}
+	if terminal.WorkDir != "" {
+		command = append(command, "--workdir", terminal.WorkDir)
+	}
+
+	envNames := make([]string, 0, len(terminal.Env))
+	for name := range terminal.Env {
+		envNames = append(envNames, name)
+	}
+	sort.Strings(envNames)
+
+	for _, name := range envNames {
+		command = append(command, "--env", name+"="+terminal.Env[name])
+	}
+
+	command = append(command, "--")
+	return append(command, terminal.Command...)
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"io"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/mgutz/ansi"
+)
+
+// sourceColors holds the colors of the sources of session record entries
+var sourceColors = map[string]string{
+	log.SourceDevSpace: "cyan+b",
+	log.SourceBuild:    "magenta+b",
+	log.SourceDeploy:   "green+b",
+	log.SourceSync:     "yellow+b",
+}
+
+var podColors = []string{
+	"blue",
+	"green",
+	"yellow",
+	"magenta",
+	"cyan",
+	"red",
+	"white+b",
+}
+
+// SessionRecordOptions defines which entries of a session record are printed
+type SessionRecordOptions struct {
+	// Since only prints entries that were recorded after this time
+	Since time.Time
+
+	// Sources only prints entries of these sources (e.g. build, deploy, sync, logs)
+	Sources []string
+
+	// Pod and Container only print the container logs of this pod or container
+	Pod       string
+	Container string
+
+	Formatter *kubectl.LogFormatter
+}
+
+// ReplaySessionRecord prints the entries of the session record with the given name (or log.LastSession)
+func ReplaySessionRecord(name string, options *SessionRecordOptions, writer io.Writer) error {
+	records, err := log.ReadSessionRecord(name)
+	if err != nil {
+		return err
+	}
+
+	printSessionRecords(records, options, writer)
+	return nil
+}
+
+func printSessionRecords(records []*log.SessionRecord, options *SessionRecordOptions, writer io.Writer) {
+	colors := map[string]string{}
+	for _, record := range records {
+		if isSessionRecordSelected(record, options) == false {
+			continue
+		}
+
+		message, ok := options.Formatter.Format(record.Message)
+		if ok == false {
+			continue
+		}
+
+		prefix := ansi.Color("["+record.Source+"]", sourceColors[record.Source])
+		if record.Source == log.SourceLogs {
+			color, ok := colors[record.Pod]
+			if ok == false {
+				color = podColors[len(colors)%len(podColors)]
+				colors[record.Pod] = color
+			}
+
+			prefix = ansi.Color("["+record.Pod+":"+record.Container+"]", color)
+		}
+
+		switch record.Level {
+		case "warning":
+			message = ansi.Color("WARN ", "red+b") + message
+		case "error":
+			message = ansi.Color("ERROR ", "red+b") + message
+		}
+
+		writer.Write([]byte(ansi.Color(record.Time.Local().Format("15:04:05"), "white") + " " + prefix + " " + message + "\n"))
+	}
+}
+
+func isSessionRecordSelected(record *log.SessionRecord, options *SessionRecordOptions) bool {
+	if options.Since.IsZero() == false && record.Time.Before(options.Since) {
+		return false
+	}
+
+	if len(options.Sources) > 0 {
+		found := false
+		for _, source := range options.Sources {
+			if source == record.Source {
+				found = true
+				break
+			}
+		}
+		if found == false {
+			return false
+		}
+	}
+
+	if options.Pod != "" && record.Pod != options.Pod {
+		return false
+	} else if options.Container != "" && record.Container != options.Container {
+		return false
+	}
+
+	return true
+}
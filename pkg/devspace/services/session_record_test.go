@@ -0,0 +1,124 @@
+package services
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/mgutz/ansi"
+
+	"gotest.tools/assert"
+)
+
+type replaySessionRecordTestCase struct {
+	name string
+
+	options *SessionRecordOptions
+
+	expectedMessages []string
+}
+
+func TestReplaySessionRecord(t *testing.T) {
+	ansi.DisableColors(true)
+	defer ansi.DisableColors(false)
+
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	logdirBackup := log.Logdir
+	log.Logdir = dir + "/"
+	defer func() { log.Logdir = logdirBackup }()
+
+	err = ReplaySessionRecord(log.LastSession, &SessionRecordOptions{}, &bytes.Buffer{})
+	assert.Error(t, err, "No session records found. Sessions are recorded while running `devspace dev`")
+
+	start := time.Now()
+	_, err = log.StartSessionRecording()
+	assert.NilError(t, err, "Error starting session recording")
+
+	log.SetSessionSource(log.SourceBuild)
+	buildOutput := log.RecordWriter(&bytes.Buffer{})
+	buildOutput.Write([]byte("Step 1/2 : FROM \x1b[1malpine\x1b[0m\nStep 2/2"))
+	buildOutput.Write([]byte(" : RUN make\n"))
+	log.SetSessionSource(log.SourceDevSpace)
+
+	log.RecordContainerLog("api-1", "api", "GET /healthz 200")
+	log.RecordContainerLog("api-1", "api", `{"level":"error","msg":"request failed"}`)
+	log.RecordContainerLog("worker-1", "worker", "job done")
+	log.StopSessionRecording()
+
+	testCases := []replaySessionRecordTestCase{
+		replaySessionRecordTestCase{
+			name:    "All entries",
+			options: &SessionRecordOptions{},
+			expectedMessages: []string{
+				"[build] Step 1/2 : FROM alpine",
+				"[build] Step 2/2 : RUN make",
+				"[api-1:api] GET /healthz 200",
+				`[api-1:api] {"level":"error","msg":"request failed"}`,
+				"[worker-1:worker] job done",
+			},
+		},
+		replaySessionRecordTestCase{
+			name: "Container logs of a pod",
+			options: &SessionRecordOptions{
+				Sources: []string{log.SourceLogs},
+				Pod:     "api-1",
+				Formatter: &kubectl.LogFormatter{
+					FormatJSON: true,
+				},
+			},
+			expectedMessages: []string{
+				"[api-1:api] GET /healthz 200",
+				"[api-1:api] ERROR request failed",
+			},
+		},
+		replaySessionRecordTestCase{
+			name: "Since",
+			options: &SessionRecordOptions{
+				Since: time.Now().Add(time.Hour),
+			},
+			expectedMessages: []string{},
+		},
+		replaySessionRecordTestCase{
+			name: "Since start",
+			options: &SessionRecordOptions{
+				Since:   start,
+				Sources: []string{log.SourceBuild},
+			},
+			expectedMessages: []string{
+				"[build] Step 1/2 : FROM alpine",
+				"[build] Step 2/2 : RUN make",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		buffer := &bytes.Buffer{}
+		err := ReplaySessionRecord(log.LastSession, testCase.options, buffer)
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+
+		messages := []string{}
+		for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+			if line == "" {
+				continue
+			}
+
+			// Remove the time
+			messages = append(messages, line[strings.Index(line, " ")+1:])
+		}
+
+		assert.DeepEqual(t, messages, testCase.expectedMessages)
+	}
+
+	err = ReplaySessionRecord("20000101-000000", &SessionRecordOptions{}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "Session record 20000101-000000 not found")
+}
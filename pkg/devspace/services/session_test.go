@@ -0,0 +1,48 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+
+	"gotest.tools/assert"
+)
+
+type getSessionCommandTestCase struct {
+	name string
+
+	terminal *latest.TerminalSessionConfig
+	detach   bool
+
+	expectedCommand []string
+}
+
+func TestGetSessionCommand(t *testing.T) {
+	testCases := []getSessionCommandTestCase{
+		getSessionCommandTestCase{
+			name: "Default shell",
+			terminal: &latest.TerminalSessionConfig{
+				Name: "shell",
+			},
+			expectedCommand: []string{SyncHelperContainerPath, "--session", "shell", "--"},
+		},
+		getSessionCommandTestCase{
+			name: "Detached session with options",
+			terminal: &latest.TerminalSessionConfig{
+				Name:    "migrate",
+				Command: []string{"npm", "run", "migrate"},
+				WorkDir: "/app",
+				Env: map[string]string{
+					"NODE_ENV": "development",
+					"DEBUG":    "true",
+				},
+			},
+			detach:          true,
+			expectedCommand: []string{SyncHelperContainerPath, "--session", "migrate", "--detach", "--workdir", "/app", "--env", "DEBUG=true", "--env", "NODE_ENV=development", "--", "npm", "run", "migrate"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.DeepEqual(t, getSessionCommand(testCase.terminal, testCase.detach), testCase.expectedCommand)
+	}
+}
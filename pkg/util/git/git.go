@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/pkg/errors"
 	git "gopkg.in/src-d/go-git.v4"
@@ -110,6 +111,11 @@ func (gr *Repository) Update(merge bool) error {
 	// Check if git command exists
 	if isGitCommandAvailable() {
 		if merge {
+			err := gr.checkoutDefaultBranch()
+			if err != nil {
+				return err
+			}
+
 			out, err := exec.Command("git", "-C", gr.LocalPath, "pull").CombinedOutput()
 			if err != nil {
 				return errors.Errorf("Error running 'git pull %s': %v -> %s", gr.RemoteURL, err, string(out))
@@ -161,6 +167,27 @@ func (gr *Repository) Update(merge bool) error {
 	return nil
 }
 
+// checkoutDefaultBranch checks out the default branch of the remote if HEAD is detached, e.g. after a revision was checked out
+func (gr *Repository) checkoutDefaultBranch() error {
+	_, err := exec.Command("git", "-C", gr.LocalPath, "symbolic-ref", "-q", "HEAD").Output()
+	if err == nil {
+		return nil
+	}
+
+	out, err := exec.Command("git", "-C", gr.LocalPath, "symbolic-ref", "--short", "refs/remotes/origin/HEAD").Output()
+	if err != nil {
+		return errors.Errorf("Error determining default branch of %s: %v", gr.RemoteURL, err)
+	}
+
+	branch := strings.TrimPrefix(strings.TrimSpace(string(out)), "origin/")
+	out, err = exec.Command("git", "-C", gr.LocalPath, "checkout", branch).CombinedOutput()
+	if err != nil {
+		return errors.Errorf("Error running 'git checkout %s': %v -> %s", branch, err, string(out))
+	}
+
+	return nil
+}
+
 // Checkout certain tag, branch or hash
 func (gr *Repository) Checkout(tag, branch, revision string) error {
 	if isGitCommandAvailable() {
@@ -0,0 +1,265 @@
+package hosts
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	startMarker = "# DevSpace hosts start - do not edit this section"
+	endMarker   = "# DevSpace hosts end"
+)
+
+// DefaultPath returns the path of the hosts file of the operating system
+func DefaultPath() string {
+	if runtime.GOOS == "windows" {
+		return os.Getenv("SystemRoot") + `\System32\drivers\etc\hosts`
+	}
+
+	return "/etc/hosts"
+}
+
+// LoopbackIP returns a stable loopback ip (127.x.y.z) for the given name. The ip is never in 127.0.0.0/16,
+// so it does not collide with 127.0.0.1 and other commonly used loopback addresses
+func LoopbackIP(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	sum := h.Sum32()
+
+	return fmt.Sprintf("127.%d.%d.%d", 1+(sum>>16)%254, (sum>>8)&0xff, 1+sum%254)
+}
+
+// ServiceHostnames returns the names a service is reachable with from inside the cluster. The bare service name is
+// not included, because it would shadow the host with the same name (e.g. localhost) for every process on the machine
+func ServiceHostnames(service, namespace string) []string {
+	return []string{
+		service + "." + namespace,
+		service + "." + namespace + ".svc",
+		service + "." + namespace + ".svc.cluster.local",
+	}
+}
+
+// Manager maintains a section in the hosts file that maps hostnames to loopback ips
+type Manager struct {
+	path string
+
+	entries map[string]*entry
+	mutex   sync.Mutex
+}
+
+type entry struct {
+	hostnames []string
+	refs      int
+}
+
+var managers = map[string]*Manager{}
+var managersMutex sync.Mutex
+
+// GetManager returns the manager for the hosts file with the given path
+func GetManager(path string) *Manager {
+	managersMutex.Lock()
+	defer managersMutex.Unlock()
+
+	if managers[path] == nil {
+		managers[path] = &Manager{
+			path:    path,
+			entries: map[string]*entry{},
+		}
+	}
+
+	return managers[path]
+}
+
+// Add maps the hostnames to the loopback ip. If the ip was already added with the same hostnames, only the reference
+// count is increased. If the ip is already used by other hostnames, an error is returned
+func (m *Manager) Add(ip string, hostnames []string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.entries[ip] != nil {
+		if equalHostnames(m.entries[ip].hostnames, hostnames) == false {
+			return errors.Errorf("Loopback ip %s is already used by %s and cannot be used for %s", ip, strings.Join(m.entries[ip].hostnames, " "), strings.Join(hostnames, " "))
+		}
+
+		m.entries[ip].refs++
+		return nil
+	}
+
+	err := addLoopbackAlias(ip)
+	if err != nil {
+		return err
+	}
+
+	m.entries[ip] = &entry{
+		hostnames: hostnames,
+		refs:      1,
+	}
+
+	err = m.write()
+	if err != nil {
+		delete(m.entries, ip)
+		removeLoopbackAlias(ip)
+		return err
+	}
+
+	return nil
+}
+
+// Remove removes the mapping of the loopback ip as soon as it is not referenced anymore
+func (m *Manager) Remove(ip string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.entries[ip] == nil {
+		return nil
+	}
+
+	m.entries[ip].refs--
+	if m.entries[ip].refs > 0 {
+		return nil
+	}
+
+	delete(m.entries, ip)
+	removeLoopbackAlias(ip)
+	return m.write()
+}
+
+func equalHostnames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *Manager) write() error {
+	content, err := ioutil.ReadFile(m.path)
+	if err != nil && os.IsNotExist(err) == false {
+		return errors.Wrapf(err, "read hosts file %s", m.path)
+	}
+
+	lines := []string{}
+	for ip, entry := range m.entries {
+		lines = append(lines, ip+" "+strings.Join(entry.hostnames, " "))
+	}
+	sort.Strings(lines)
+
+	err = writeFileAtomic(m.path, []byte(UpdateSection(string(content), lines)))
+	if err != nil {
+		return errors.Wrapf(err, "write hosts file %s", m.path)
+	}
+
+	return nil
+}
+
+// writeFileAtomic writes the content to a temporary file in the same folder and renames it to path, so that the file
+// is never left half written (e.g. if devspace is killed or the disk is full). The mode of an existing file is kept
+func writeFileAtomic(path string, content []byte) error {
+	mode := os.FileMode(0644)
+	stat, err := os.Stat(path)
+	if err == nil {
+		mode = stat.Mode().Perm()
+	} else if os.IsNotExist(err) == false {
+		return err
+	}
+
+	tempFile, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".devspace-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.Write(content)
+	if err == nil {
+		err = tempFile.Sync()
+	}
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(tempFile.Name(), mode)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), path)
+}
+
+// UpdateSection replaces the DevSpace section in the content of a hosts file with the given lines. If lines is
+// empty, the section is removed
+func UpdateSection(content string, lines []string) string {
+	newline := "\n"
+	if strings.Contains(content, "\r\n") {
+		newline = "\r\n"
+	}
+
+	kept := []string{}
+	inSection := false
+	for _, line := range strings.Split(strings.Replace(content, "\r\n", "\n", -1), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == startMarker {
+			inSection = true
+		} else if trimmed == endMarker {
+			inSection = false
+		} else if inSection == false {
+			kept = append(kept, line)
+		}
+	}
+
+	// Remove trailing empty lines
+	for len(kept) > 0 && strings.TrimSpace(kept[len(kept)-1]) == "" {
+		kept = kept[:len(kept)-1]
+	}
+
+	if len(lines) > 0 {
+		kept = append(kept, startMarker)
+		kept = append(kept, lines...)
+		kept = append(kept, endMarker)
+	}
+	if len(kept) == 0 {
+		return ""
+	}
+
+	return strings.Join(kept, newline) + newline
+}
+
+// addLoopbackAlias makes the ip usable on macOS, which only configures 127.0.0.1 on the loopback interface
+func addLoopbackAlias(ip string) error {
+	if runtime.GOOS != "darwin" {
+		return nil
+	}
+
+	out, err := exec.Command("ifconfig", "lo0", "alias", ip, "up").CombinedOutput()
+	if err != nil {
+		return errors.Errorf("Error adding loopback alias %s: %s => %v", ip, strings.TrimSpace(string(out)), err)
+	}
+
+	return nil
+}
+
+func removeLoopbackAlias(ip string) {
+	if runtime.GOOS != "darwin" {
+		return
+	}
+
+	exec.Command("ifconfig", "lo0", "-alias", ip).Run()
+}
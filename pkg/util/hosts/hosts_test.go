@@ -0,0 +1,109 @@
+package hosts
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+type updateSectionTestCase struct {
+	name string
+
+	content string
+	lines   []string
+
+	expected string
+}
+
+func TestUpdateSection(t *testing.T) {
+	section := startMarker + "\n127.1.2.3 api api.default\n" + endMarker + "\n"
+
+	testCases := []updateSectionTestCase{
+		updateSectionTestCase{
+			name:     "Add section",
+			content:  "127.0.0.1 localhost\n",
+			lines:    []string{"127.1.2.3 api api.default"},
+			expected: "127.0.0.1 localhost\n" + section,
+		},
+		updateSectionTestCase{
+			name:     "Replace section",
+			content:  "127.0.0.1 localhost\n" + startMarker + "\n127.4.5.6 old\n" + endMarker + "\n::1 localhost\n",
+			lines:    []string{"127.1.2.3 api api.default"},
+			expected: "127.0.0.1 localhost\n::1 localhost\n" + section,
+		},
+		updateSectionTestCase{
+			name:     "Remove section",
+			content:  "127.0.0.1 localhost\n\n" + section,
+			expected: "127.0.0.1 localhost\n",
+		},
+		updateSectionTestCase{
+			name:     "Keep windows line endings",
+			content:  "127.0.0.1 localhost\r\n",
+			lines:    []string{"127.1.2.3 api api.default"},
+			expected: "127.0.0.1 localhost\r\n" + strings.Replace(section, "\n", "\r\n", -1),
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, UpdateSection(testCase.content, testCase.lines), testCase.expected, "Unexpected content in testCase %s", testCase.name)
+	}
+}
+
+func TestServiceHostnames(t *testing.T) {
+	assert.DeepEqual(t, ServiceHostnames("api", "default"), []string{"api.default", "api.default.svc", "api.default.svc.cluster.local"})
+}
+
+func TestLoopbackIP(t *testing.T) {
+	ip := LoopbackIP("api.default")
+	assert.Equal(t, ip, LoopbackIP("api.default"), "Loopback ip is not stable")
+	assert.Assert(t, ip != LoopbackIP("api.other"), "Different names got the same loopback ip")
+	assert.Assert(t, strings.HasPrefix(ip, "127.") && strings.HasPrefix(ip, "127.0.") == false, "Unexpected loopback ip %s", ip)
+}
+
+func TestManager(t *testing.T) {
+	if runtime.GOOS == "darwin" {
+		t.Skip("Adding loopback aliases requires admin rights on macOS")
+	}
+
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "hosts")
+	err = ioutil.WriteFile(path, []byte("127.0.0.1 localhost\n"), 0600)
+	assert.NilError(t, err, "Error writing hosts file")
+
+	manager := GetManager(path)
+	assert.NilError(t, manager.Add("127.1.2.3", []string{"api"}), "Error adding entry")
+	assert.NilError(t, manager.Add("127.1.2.3", []string{"api"}), "Error adding entry twice")
+	assert.Error(t, manager.Add("127.1.2.3", []string{"other"}), "Loopback ip 127.1.2.3 is already used by api and cannot be used for other", "Colliding entry was added")
+
+	content, _ := ioutil.ReadFile(path)
+	assert.Equal(t, string(content), "127.0.0.1 localhost\n"+startMarker+"\n127.1.2.3 api\n"+endMarker+"\n")
+
+	// The hosts file is replaced with a temporary file that keeps the mode
+	stat, err := os.Stat(path)
+	assert.NilError(t, err, "Error reading hosts file")
+	if runtime.GOOS != "windows" {
+		assert.Equal(t, stat.Mode().Perm(), os.FileMode(0600), "Mode of the hosts file was changed")
+	}
+	files, err := ioutil.ReadDir(dir)
+	assert.NilError(t, err, "Error reading directory")
+	assert.Equal(t, len(files), 1, "Temporary file was not removed")
+
+	// The entry is still referenced once
+	assert.NilError(t, manager.Remove("127.1.2.3"), "Error removing entry")
+	content, _ = ioutil.ReadFile(path)
+	assert.Assert(t, strings.Contains(string(content), "127.1.2.3 api"), "Entry was removed too early")
+
+	assert.NilError(t, manager.Remove("127.1.2.3"), "Error removing entry")
+	content, _ = ioutil.ReadFile(path)
+	assert.Equal(t, string(content), "127.0.0.1 localhost\n")
+}
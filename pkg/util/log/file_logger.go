@@ -31,6 +31,8 @@ func GetFileLogger(filename string) Logger {
 			logger: logrus.New(),
 		}
 		newLogger.logger.Formatter = &logrus.JSONFormatter{}
+		newLogger.logger.AddHook(&maskHook{})
+		newLogger.logger.AddHook(newSessionHook(filename))
 
 		os.MkdirAll(Logdir, os.ModePerm)
 
@@ -191,9 +193,9 @@ func (f *fileLogger) GetLevel() logrus.Level {
 }
 
 func (f *fileLogger) Write(message []byte) (int, error) {
-	return f.logger.Out.Write(message)
+	return (&maskWriter{f.logger.Out}).Write(message)
 }
 
 func (f *fileLogger) WriteString(message string) {
-	f.logger.Out.Write([]byte(message))
+	f.logger.Out.Write([]byte(Mask(message)))
 }
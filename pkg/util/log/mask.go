@@ -0,0 +1,92 @@
+package log
+
+import (
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MaskedValue replaces sensitive values in the log output
+const MaskedValue = "******"
+
+// minSensitiveLength is the minimum length of a value that is masked. Shorter values would mask large parts of the output
+const minSensitiveLength = 3
+
+var (
+	sensitiveValues      = []string{}
+	sensitiveValuesMutex sync.RWMutex
+)
+
+// AddSensitive registers a value that should never show up in the log output
+func AddSensitive(value string) {
+	if len(strings.TrimSpace(value)) < minSensitiveLength {
+		return
+	}
+
+	sensitiveValuesMutex.Lock()
+	defer sensitiveValuesMutex.Unlock()
+
+	for _, existing := range sensitiveValues {
+		if existing == value {
+			return
+		}
+	}
+
+	sensitiveValues = append(sensitiveValues, value)
+}
+
+// Mask replaces all registered sensitive values in the message
+func Mask(message string) string {
+	sensitiveValuesMutex.RLock()
+	defer sensitiveValuesMutex.RUnlock()
+
+	for _, value := range sensitiveValues {
+		message = strings.Replace(message, value, MaskedValue, -1)
+	}
+
+	return message
+}
+
+// maskWriter masks sensitive values before writing to the underlying writer
+type maskWriter struct {
+	writer io.Writer
+}
+
+func (m *maskWriter) Write(message []byte) (int, error) {
+	_, err := m.writer.Write([]byte(Mask(string(message))))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(message), nil
+}
+
+// maskFields returns a copy of the fields with all registered sensitive values masked in the string values
+func maskFields(fields logrus.Fields) logrus.Fields {
+	masked := logrus.Fields{}
+	for key, value := range fields {
+		if str, ok := value.(string); ok {
+			value = Mask(str)
+		}
+
+		masked[key] = value
+	}
+
+	return masked
+}
+
+// maskHook masks sensitive values in the message and fields of an entry before it is formatted. Masking the formatted
+// output is not enough, because formatters escape characters (e.g. quotes in json) of sensitive values
+type maskHook struct{}
+
+func (h *maskHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *maskHook) Fire(entry *logrus.Entry) error {
+	entry.Message = Mask(entry.Message)
+	entry.Data = maskFields(entry.Data)
+	return nil
+}
@@ -0,0 +1,360 @@
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// SessionsDir is the folder within Logdir that holds the session records of devspace dev
+var SessionsDir = "sessions"
+
+// LastSession is the name that references the newest session record
+const LastSession = "last"
+
+// maxSessionRecords is the number of session records that are kept
+var maxSessionRecords = 10
+
+const sessionRecordExtension = ".jsonl"
+
+// sessionNameFormat is the time format of the names of the session records
+const sessionNameFormat = "20060102-150405"
+
+// Sources of the entries of a session record
+const (
+	SourceDevSpace = "devspace"
+	SourceBuild    = "build"
+	SourceDeploy   = "deploy"
+	SourceSync     = "sync"
+	SourceLogs     = "logs"
+)
+
+// fileLoggerSources maps the file loggers to the source of their entries in the session record. Entries of file
+// loggers that are not listed use the name of the file logger as source
+var fileLoggerSources = map[string]string{
+	"sync": SourceSync,
+}
+
+var ansiRegEx = regexp.MustCompile(`\x1b\[[0-9;?]*[a-zA-Z]`)
+
+// SessionRecord is an entry of a session record
+type SessionRecord struct {
+	Time      time.Time `json:"time"`
+	Level     string    `json:"level"`
+	Source    string    `json:"source"`
+	Pod       string    `json:"pod,omitempty"`
+	Container string    `json:"container,omitempty"`
+	Message   string    `json:"msg"`
+}
+
+type sessionRecorder struct {
+	name   string
+	logger *logrus.Logger
+	file   *os.File
+
+	// source is the source of the entries of the global logger
+	source string
+
+	// partialLines holds output of writers that doesn't end with a newline yet
+	partialLines map[string][]byte
+
+	mutex sync.Mutex
+}
+
+var recorder *sessionRecorder
+var recorderMutex sync.Mutex
+
+// StartSessionRecording starts a new session record in Logdir/SessionsDir and returns its name. The record contains
+// the messages of the global logger and the file loggers, as well as the container logs and build output
+func StartSessionRecording() (string, error) {
+	recorderMutex.Lock()
+	defer recorderMutex.Unlock()
+
+	if recorder != nil {
+		return recorder.name, nil
+	}
+
+	sessionsPath := filepath.Join(Logdir, SessionsDir)
+	err := os.MkdirAll(sessionsPath, os.ModePerm)
+	if err != nil {
+		return "", err
+	}
+
+	// Remove old session records
+	err = cleanupSessionRecords(sessionsPath, maxSessionRecords-1)
+	if err != nil {
+		return "", errors.Wrap(err, "cleanup session records")
+	}
+
+	name := time.Now().Format(sessionNameFormat)
+	file, err := os.OpenFile(filepath.Join(sessionsPath, name+sessionRecordExtension), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return "", err
+	}
+
+	logger := logrus.New()
+	logger.Formatter = &logrus.JSONFormatter{TimestampFormat: time.RFC3339Nano}
+	logger.SetOutput(file)
+	logger.SetLevel(logrus.InfoLevel)
+
+	recorder = &sessionRecorder{
+		name:         name,
+		logger:       logger,
+		file:         file,
+		source:       SourceDevSpace,
+		partialLines: map[string][]byte{},
+	}
+
+	return name, nil
+}
+
+// StopSessionRecording stops the current session record
+func StopSessionRecording() {
+	recorderMutex.Lock()
+	defer recorderMutex.Unlock()
+
+	if recorder != nil {
+		recorder.file.Close()
+		recorder = nil
+	}
+}
+
+// SetSessionSource sets the source of the entries of the global logger in the session record, e.g. SourceBuild while
+// images are built
+func SetSessionSource(source string) {
+	recorderMutex.Lock()
+	defer recorderMutex.Unlock()
+
+	if recorder != nil {
+		recorder.mutex.Lock()
+		recorder.source = source
+		recorder.mutex.Unlock()
+	}
+}
+
+// RecordContainerLog adds a log line of a container to the session record
+func RecordContainerLog(pod, container, line string) {
+	record(logrus.InfoLevel, SourceLogs, logrus.Fields{"pod": pod, "container": container}, line)
+}
+
+// RecordWriter returns a writer that writes to the given writer and adds every written line to the session record.
+// If no session is recorded, the given writer is returned
+func RecordWriter(writer io.Writer) io.Writer {
+	recorderMutex.Lock()
+	defer recorderMutex.Unlock()
+
+	if recorder == nil {
+		return writer
+	}
+
+	return io.MultiWriter(writer, &recordWriter{})
+}
+
+type recordWriter struct{}
+
+func (w *recordWriter) Write(message []byte) (int, error) {
+	recordOutput("", message)
+	return len(message), nil
+}
+
+// recordOutput adds the complete lines of the output of the global logger to the session record. The source is
+// the current source of the global logger, if no source is given
+func recordOutput(source string, message []byte) {
+	recorderMutex.Lock()
+	currentRecorder := recorder
+	recorderMutex.Unlock()
+	if currentRecorder == nil {
+		return
+	}
+
+	currentRecorder.mutex.Lock()
+	if source == "" {
+		source = currentRecorder.source
+	}
+
+	// Progress output (e.g. of docker builds) uses carriage returns to overwrite lines
+	message = bytes.Replace(message, []byte("\r"), []byte("\n"), -1)
+	output := append(currentRecorder.partialLines[source], message...)
+	lastNewline := bytes.LastIndexByte(output, '\n')
+	currentRecorder.partialLines[source] = append([]byte{}, output[lastNewline+1:]...)
+	currentRecorder.mutex.Unlock()
+
+	if lastNewline == -1 {
+		return
+	}
+
+	for _, line := range strings.Split(string(output[:lastNewline]), "\n") {
+		line = strings.TrimSpace(ansiRegEx.ReplaceAllString(line, ""))
+		if line != "" {
+			record(logrus.InfoLevel, source, nil, line)
+		}
+	}
+}
+
+func record(level logrus.Level, source string, fields logrus.Fields, message string) {
+	recorderMutex.Lock()
+	currentRecorder := recorder
+	recorderMutex.Unlock()
+	if currentRecorder == nil {
+		return
+	}
+
+	if source == "" {
+		currentRecorder.mutex.Lock()
+		source = currentRecorder.source
+		currentRecorder.mutex.Unlock()
+	}
+
+	// Sensitive values are masked before the entry is json encoded, which would escape some of their characters
+	message = Mask(message)
+	entry := currentRecorder.logger.WithFields(maskFields(fields)).WithField("source", source)
+	switch level {
+	case logrus.DebugLevel:
+		entry.Debug(message)
+	case logrus.WarnLevel:
+		entry.Warn(message)
+	case logrus.ErrorLevel, logrus.FatalLevel, logrus.PanicLevel:
+		// Fatal and panic entries would exit the program, so they are recorded as errors
+		entry.Error(message)
+	default:
+		entry.Info(message)
+	}
+}
+
+// sessionHook adds the entries of a file logger to the session record
+type sessionHook struct {
+	source string
+}
+
+func (h *sessionHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *sessionHook) Fire(entry *logrus.Entry) error {
+	record(entry.Level, h.source, nil, strings.TrimSpace(ansiRegEx.ReplaceAllString(entry.Message, "")))
+	return nil
+}
+
+// newSessionHook returns the hook for the file logger with the given filename. Entries of the default file logger
+// use the current source of the global logger
+func newSessionHook(filename string) *sessionHook {
+	if filename == "default" {
+		return &sessionHook{}
+	} else if source, ok := fileLoggerSources[filename]; ok {
+		return &sessionHook{source: source}
+	}
+
+	return &sessionHook{source: filename}
+}
+
+// ListSessionRecords returns the names of the session records, the newest first
+func ListSessionRecords() ([]string, error) {
+	files, err := ioutil.ReadDir(filepath.Join(Logdir, SessionsDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+
+		return nil, err
+	}
+
+	names := []string{}
+	for _, file := range files {
+		if file.IsDir() == false && strings.HasSuffix(file.Name(), sessionRecordExtension) {
+			names = append(names, strings.TrimSuffix(file.Name(), sessionRecordExtension))
+		}
+	}
+
+	// Names are timestamps, so the newest record has the greatest name
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	return names, nil
+}
+
+// ReadSessionRecord reads the entries of the session record with the given name (or LastSession)
+func ReadSessionRecord(name string) ([]*SessionRecord, error) {
+	names, err := ListSessionRecords()
+	if err != nil {
+		return nil, err
+	} else if len(names) == 0 {
+		return nil, errors.New("No session records found. Sessions are recorded while running `devspace dev`")
+	}
+
+	if name == LastSession {
+		name = names[0]
+	} else {
+		found := false
+		for _, existingName := range names {
+			if existingName == name {
+				found = true
+				break
+			}
+		}
+		if found == false {
+			return nil, errors.Errorf("Session record %s not found. Available session records: %s", name, strings.Join(names, ", "))
+		}
+	}
+
+	file, err := os.Open(filepath.Join(Logdir, SessionsDir, name+sessionRecordExtension))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parseSessionRecord(file)
+}
+
+func parseSessionRecord(reader io.Reader) ([]*SessionRecord, error) {
+	records := []*SessionRecord{}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		record := &SessionRecord{}
+		err := json.Unmarshal(scanner.Bytes(), record)
+		if err != nil {
+			// Skip entries that were not written completely
+			continue
+		}
+
+		records = append(records, record)
+	}
+
+	return records, scanner.Err()
+}
+
+// cleanupSessionRecords removes the oldest session records, so that at most keep records are left
+func cleanupSessionRecords(sessionsPath string, keep int) error {
+	names, err := ListSessionRecords()
+	if err != nil {
+		return err
+	}
+
+	for index, name := range names {
+		if index < keep {
+			continue
+		}
+
+		err = os.Remove(filepath.Join(sessionsPath, name+sessionRecordExtension))
+		if err != nil && os.IsNotExist(err) == false {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -88,7 +88,7 @@ func (s *stdoutLogger) writeMessage(fnType logFunctionType, message string) {
 		}
 
 		fnInformation.stream.Write([]byte(ansi.Color(fnInformation.tag, fnInformation.color)))
-		fnInformation.stream.Write([]byte(message))
+		fnInformation.stream.Write([]byte(Mask(message)))
 
 		if s.loadingText != nil && fnType != fatalFn {
 			s.loadingText.Start()
@@ -152,7 +152,7 @@ func (s *stdoutLogger) StartWait(message string) {
 	defer s.logMutex.Unlock()
 
 	if s.loadingText != nil {
-		if s.loadingText.Message == message {
+		if s.loadingText.Message == Mask(message) {
 			return
 		}
 
@@ -162,7 +162,7 @@ func (s *stdoutLogger) StartWait(message string) {
 
 	if s.level >= logrus.InfoLevel {
 		s.loadingText = &loadingText{
-			Message: message,
+			Message: Mask(message),
 			Stream:  goansi.NewAnsiStdout(),
 		}
 
@@ -387,7 +387,8 @@ func (s *stdoutLogger) Write(message []byte) (int, error) {
 			s.loadingText.Stop()
 		}
 
-		n, err := fnTypeInformationMap[infoFn].stream.Write(message)
+		n, err := (&maskWriter{fnTypeInformationMap[infoFn].stream}).Write(message)
+		recordOutput("", message)
 
 		if s.loadingText != nil {
 			s.loadingText.Start()
@@ -408,7 +409,8 @@ func (s *stdoutLogger) WriteString(message string) {
 			s.loadingText.Stop()
 		}
 
-		fnTypeInformationMap[infoFn].stream.Write([]byte(message))
+		fnTypeInformationMap[infoFn].stream.Write([]byte(Mask(message)))
+		recordOutput("", []byte(message))
 
 		if s.loadingText != nil {
 			s.loadingText.Start()
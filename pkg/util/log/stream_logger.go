@@ -75,7 +75,7 @@ func (s *StreamLogger) writeMessage(fnType logFunctionType, message string) {
 			panic(err)
 		}
 
-		_, err = s.stream.Write([]byte(message))
+		_, err = s.stream.Write([]byte(Mask(message)))
 		if err != nil {
 			panic(err)
 		}
@@ -293,7 +293,7 @@ func (s *StreamLogger) Write(message []byte) (int, error) {
 	s.logMutex.Lock()
 	defer s.logMutex.Unlock()
 
-	return s.stream.Write(message)
+	return (&maskWriter{s.stream}).Write(message)
 }
 
 // WriteString implements interface
@@ -301,7 +301,7 @@ func (s *StreamLogger) WriteString(message string) {
 	s.logMutex.Lock()
 	defer s.logMutex.Unlock()
 
-	_, err := s.stream.Write([]byte(message))
+	_, err := s.stream.Write([]byte(Mask(message)))
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,211 @@
+package session
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Ctrl+P followed by Ctrl+Q detaches the client from the session
+const (
+	detachPrefix byte = 0x10
+	detachKey    byte = 0x11
+)
+
+// connectTimeout is the time to wait for a newly started session to accept connections
+var connectTimeout = time.Second * 5
+
+// startServer starts the server of the session in the background. The server is started in a new process
+// session, so that it keeps running if the connection to the container breaks
+var startServer = func(name string, options *Options) error {
+	args := []string{"--session-server", name}
+	if options.WorkDir != "" {
+		args = append(args, "--workdir", options.WorkDir)
+	}
+	for _, env := range options.Env {
+		args = append(args, "--env", env)
+	}
+	args = append(args, "--")
+	args = append(args, options.Command...)
+
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.SysProcAttr = detachedProcAttr()
+
+	err := cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	return cmd.Process.Release()
+}
+
+// Start starts the session if it does not exist yet without attaching to it
+func Start(name string, options *Options) error {
+	conn, err := connect(name, options)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// Attach attaches the standard input and output to the session with the given name. If the session does not
+// exist yet, it is started with the options. Attach returns the exit code of the command of the session or 0 if
+// the client detached
+func Attach(name string, options *Options) (int, error) {
+	conn, err := connect(name, options)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	restore, err := makeRaw(os.Stdin)
+	if err == nil && restore != nil {
+		defer restore()
+	}
+
+	writer := &frameWriter{writer: conn}
+	sendSize := func() {
+		rows, cols, err := getWinsize(os.Stdin)
+		if err == nil {
+			writer.writeResize(rows, cols)
+		}
+	}
+
+	sendSize()
+	stopResize := watchResize(sendSize)
+	defer stopResize()
+
+	exitCode, detached, err := attach(conn, writer, os.Stdin, os.Stdout)
+	if detached {
+		fmt.Fprintf(os.Stdout, "\r\n[detached from session %s]\r\n", name)
+	}
+
+	return exitCode, err
+}
+
+// attach forwards the input to the session and the output of the session to stdout until the session exits,
+// the client detaches or the input is closed
+func attach(conn net.Conn, writer *frameWriter, stdin io.Reader, stdout io.Writer) (int, bool, error) {
+	type result struct {
+		exitCode int
+		err      error
+	}
+
+	detachChan := make(chan bool, 1)
+	go func() {
+		detached, _ := copyInput(stdin, writer)
+		detachChan <- detached
+	}()
+
+	resultChan := make(chan result, 1)
+	go func() {
+		for {
+			frameType, payload, err := readFrame(conn)
+			if err != nil {
+				resultChan <- result{err: errors.New("Connection to session closed")}
+				return
+			}
+
+			switch frameType {
+			case frameData:
+				_, err = stdout.Write(payload)
+				if err != nil {
+					resultChan <- result{err: err}
+					return
+				}
+			case frameExit:
+				resultChan <- result{exitCode: parseExit(payload)}
+				return
+			}
+		}
+	}()
+
+	select {
+	case detached := <-detachChan:
+		// The input was closed (e.g. because the connection to the container broke), so the session keeps running
+		return 0, detached, nil
+	case result := <-resultChan:
+		return result.exitCode, false, result.err
+	}
+}
+
+// copyInput sends the input to the session until the input is closed or the detach keys are pressed
+func copyInput(stdin io.Reader, writer *frameWriter) (bool, error) {
+	var (
+		buf     = make([]byte, 1024)
+		pending = false
+	)
+
+	for {
+		n, err := stdin.Read(buf)
+
+		out := make([]byte, 0, n+1)
+		for _, b := range buf[:n] {
+			if pending {
+				pending = false
+				if b == detachKey {
+					if len(out) > 0 {
+						writer.writeFrame(frameData, out)
+					}
+
+					return true, nil
+				}
+
+				out = append(out, detachPrefix)
+			}
+
+			if b == detachPrefix {
+				pending = true
+				continue
+			}
+
+			out = append(out, b)
+		}
+
+		if len(out) > 0 {
+			writeErr := writer.writeFrame(frameData, out)
+			if writeErr != nil {
+				return false, writeErr
+			}
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+// connect connects to the socket of the session and starts the session if it does not exist yet
+func connect(name string, options *Options) (net.Conn, error) {
+	socketPath, err := SocketPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := net.Dial("unix", socketPath)
+	if err == nil {
+		return conn, nil
+	}
+
+	err = startServer(name, options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "start session %s", name)
+	}
+
+	deadline := time.Now().Add(connectTimeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond * 100)
+
+		conn, err = net.Dial("unix", socketPath)
+		if err == nil {
+			return conn, nil
+		}
+	}
+
+	return nil, errors.Wrapf(err, "connect to session %s", name)
+}
@@ -0,0 +1,35 @@
+// +build !linux
+
+package session
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+func startWithPty(cmd *exec.Cmd) (*os.File, error) {
+	return nil, errors.New("Sessions are only supported in linux containers")
+}
+
+func detachedProcAttr() *syscall.SysProcAttr {
+	return nil
+}
+
+func setWinsize(f *os.File, rows, cols uint16) error {
+	return nil
+}
+
+func getWinsize(f *os.File) (uint16, uint16, error) {
+	return 0, 0, errors.New("Not supported")
+}
+
+func makeRaw(f *os.File) (func(), error) {
+	return nil, nil
+}
+
+func watchResize(fn func()) func() {
+	return func() {}
+}
@@ -0,0 +1,175 @@
+package session
+
+import (
+	"net"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// server serves a command running in a pseudo terminal to the clients that are connected to the socket of the session
+type server struct {
+	pty *os.File
+
+	scrollback scrollback
+	clients    map[net.Conn]*frameWriter
+	mutex      sync.Mutex
+}
+
+// Serve starts the command of the session in a pseudo terminal and serves it on the socket of the session until
+// the command exits
+func Serve(name string, options *Options) error {
+	if len(options.Command) == 0 {
+		return errors.New("No command specified")
+	}
+
+	socketPath, err := SocketPath(name)
+	if err != nil {
+		return err
+	}
+
+	err = ensureSessionsFolder()
+	if err != nil {
+		return err
+	}
+
+	// The socket of a session that didn't stop properly is still there
+	os.Remove(socketPath)
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return errors.Wrap(err, "listen")
+	}
+	defer os.Remove(socketPath)
+	defer listener.Close()
+
+	cmd := exec.Command(options.Command[0], options.Command[1:]...)
+	cmd.Dir = options.WorkDir
+	cmd.Env = append(os.Environ(), options.Env...)
+
+	pty, err := startWithPty(cmd)
+	if err != nil {
+		return errors.Wrapf(err, "start %s", options.Command[0])
+	}
+	defer pty.Close()
+
+	s := &server{
+		pty:     pty,
+		clients: map[net.Conn]*frameWriter{},
+	}
+
+	readDone := make(chan struct{})
+	go s.readOutput(readDone)
+	go s.accept(listener)
+
+	exitCode := 0
+	err = cmd.Wait()
+	if exitError, ok := err.(*exec.ExitError); ok {
+		exitCode = exitError.ExitCode()
+	} else if err != nil {
+		exitCode = 1
+	}
+
+	// Make sure the clients receive the last output of the command
+	select {
+	case <-readDone:
+	case <-time.After(time.Second):
+	}
+
+	s.exit(exitCode)
+	return nil
+}
+
+// readOutput reads the output of the command and sends it to all clients
+func (s *server) readOutput(done chan struct{}) {
+	defer close(done)
+
+	buf := make([]byte, maxPayloadSize)
+	for {
+		n, err := s.pty.Read(buf)
+		if n > 0 {
+			s.mutex.Lock()
+			s.scrollback.Write(buf[:n])
+			for conn, writer := range s.clients {
+				if writer.writeFrame(frameData, buf[:n]) != nil {
+					conn.Close()
+					delete(s.clients, conn)
+				}
+			}
+			s.mutex.Unlock()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *server) accept(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+// handle replays the scrollback to the client and forwards its input to the command until the client disconnects
+func (s *server) handle(conn net.Conn) {
+	writer := &frameWriter{writer: conn}
+
+	s.mutex.Lock()
+	if len(s.scrollback.Bytes()) > 0 {
+		err := writer.writeFrame(frameData, s.scrollback.Bytes())
+		if err != nil {
+			s.mutex.Unlock()
+			conn.Close()
+			return
+		}
+	}
+	s.clients[conn] = writer
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		delete(s.clients, conn)
+		s.mutex.Unlock()
+
+		conn.Close()
+	}()
+
+	for {
+		frameType, payload, err := readFrame(conn)
+		if err != nil {
+			return
+		}
+
+		switch frameType {
+		case frameData:
+			_, err = s.pty.Write(payload)
+			if err != nil {
+				return
+			}
+		case frameResize:
+			if rows, cols, ok := parseResize(payload); ok {
+				setWinsize(s.pty, rows, cols)
+			}
+		}
+	}
+}
+
+// exit tells all clients the exit code of the command and disconnects them
+func (s *server) exit(exitCode int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for conn, writer := range s.clients {
+		writer.writeExit(exitCode)
+		conn.Close()
+		delete(s.clients, conn)
+	}
+}
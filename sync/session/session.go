@@ -0,0 +1,153 @@
+package session
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// SessionsFolder is the folder in the container that holds the sockets of the running sessions
+var SessionsFolder = "/tmp/devspace-sessions"
+
+// scrollbackSize is the amount of output that is replayed to clients that attach to a running session
+const scrollbackSize = 64 * 1024
+
+// maxPayloadSize is the maximum size of the payload of a single frame
+const maxPayloadSize = 32 * 1024
+
+// Frame types of the session protocol. Every frame starts with a header that contains the frame type (1 byte)
+// and the length of the payload (4 bytes)
+const (
+	frameData byte = iota + 1
+	frameResize
+	frameExit
+)
+
+const headerSize = 5
+
+var nameRegEx = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// Options defines the command that is started if the session does not exist yet
+type Options struct {
+	Command []string
+	WorkDir string
+	Env     []string
+}
+
+// SocketPath returns the path of the socket of the session with the given name
+func SocketPath(name string) (string, error) {
+	if nameRegEx.MatchString(name) == false {
+		return "", errors.Errorf("Invalid session name %s: only letters, digits, '_', '.' and '-' are allowed", name)
+	}
+
+	return filepath.Join(SessionsFolder, name+".sock"), nil
+}
+
+// frameWriter writes frames and can be used concurrently
+type frameWriter struct {
+	writer io.Writer
+	mutex  sync.Mutex
+}
+
+func (w *frameWriter) writeFrame(frameType byte, payload []byte) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for {
+		chunk := payload
+		if len(chunk) > maxPayloadSize {
+			chunk = chunk[:maxPayloadSize]
+		}
+
+		frame := make([]byte, headerSize+len(chunk))
+		frame[0] = frameType
+		binary.BigEndian.PutUint32(frame[1:5], uint32(len(chunk)))
+		copy(frame[headerSize:], chunk)
+
+		_, err := w.writer.Write(frame)
+		if err != nil {
+			return err
+		}
+
+		payload = payload[len(chunk):]
+		if len(payload) == 0 {
+			return nil
+		}
+	}
+}
+
+func (w *frameWriter) writeResize(rows, cols uint16) error {
+	payload := make([]byte, 4)
+	binary.BigEndian.PutUint16(payload[0:2], rows)
+	binary.BigEndian.PutUint16(payload[2:4], cols)
+	return w.writeFrame(frameResize, payload)
+}
+
+func (w *frameWriter) writeExit(exitCode int) error {
+	payload := make([]byte, 4)
+	binary.BigEndian.PutUint32(payload, uint32(int32(exitCode)))
+	return w.writeFrame(frameExit, payload)
+}
+
+// readFrame reads the next frame from the reader
+func readFrame(reader io.Reader) (byte, []byte, error) {
+	header := make([]byte, headerSize)
+	_, err := io.ReadFull(reader, header)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	length := binary.BigEndian.Uint32(header[1:5])
+	if length > maxPayloadSize {
+		return 0, nil, errors.Errorf("frame payload of %d bytes exceeds the maximum of %d bytes", length, maxPayloadSize)
+	}
+
+	payload := make([]byte, length)
+	_, err = io.ReadFull(reader, payload)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return header[0], payload, nil
+}
+
+func parseResize(payload []byte) (uint16, uint16, bool) {
+	if len(payload) != 4 {
+		return 0, 0, false
+	}
+
+	return binary.BigEndian.Uint16(payload[0:2]), binary.BigEndian.Uint16(payload[2:4]), true
+}
+
+func parseExit(payload []byte) int {
+	if len(payload) != 4 {
+		return 1
+	}
+
+	return int(int32(binary.BigEndian.Uint32(payload)))
+}
+
+// scrollback holds the last output of a session
+type scrollback struct {
+	buffer []byte
+}
+
+func (s *scrollback) Write(p []byte) {
+	s.buffer = append(s.buffer, p...)
+	if len(s.buffer) > scrollbackSize {
+		s.buffer = append([]byte{}, s.buffer[len(s.buffer)-scrollbackSize:]...)
+	}
+}
+
+func (s *scrollback) Bytes() []byte {
+	return s.buffer
+}
+
+func ensureSessionsFolder() error {
+	return os.MkdirAll(SessionsFolder, 0700)
+}
@@ -0,0 +1,165 @@
+package session
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+type syncBuffer struct {
+	buffer bytes.Buffer
+	mutex  sync.Mutex
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buffer.String()
+}
+
+type copyInputTestCase struct {
+	name string
+
+	input string
+
+	expectedOutput   string
+	expectedDetached bool
+}
+
+func TestCopyInput(t *testing.T) {
+	testCases := []copyInputTestCase{
+		copyInputTestCase{
+			name:           "Plain input",
+			input:          "ls -la\r",
+			expectedOutput: "ls -la\r",
+		},
+		copyInputTestCase{
+			name:             "Detach",
+			input:            "ls\x10\x11exit\r",
+			expectedOutput:   "ls",
+			expectedDetached: true,
+		},
+		copyInputTestCase{
+			name:           "Ctrl+P without Ctrl+Q",
+			input:          "\x10\x10a",
+			expectedOutput: "\x10\x10a",
+		},
+	}
+
+	for _, testCase := range testCases {
+		output := &bytes.Buffer{}
+		detached, _ := copyInput(strings.NewReader(testCase.input), &frameWriter{writer: output})
+		assert.Equal(t, detached, testCase.expectedDetached, "Unexpected detached in testCase %s", testCase.name)
+
+		received := ""
+		for {
+			frameType, payload, err := readFrame(output)
+			if err != nil {
+				break
+			}
+
+			assert.Equal(t, frameType, frameData, "Unexpected frame type in testCase %s", testCase.name)
+			received += string(payload)
+		}
+
+		assert.Equal(t, received, testCase.expectedOutput, "Unexpected output in testCase %s", testCase.name)
+	}
+}
+
+func TestSession(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("Sessions are only supported on linux")
+	}
+	if _, err := os.Stat("/dev/ptmx"); err != nil {
+		t.Skip("No pseudo terminals available")
+	}
+
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	sessionsFolderBackup := SessionsFolder
+	startServerBackup := startServer
+	defer func() {
+		SessionsFolder = sessionsFolderBackup
+		startServer = startServerBackup
+	}()
+
+	SessionsFolder = dir
+	startServer = func(name string, options *Options) error {
+		go Serve(name, options)
+		return nil
+	}
+
+	options := &Options{
+		Command: []string{"sh", "-c", "echo ready; read line; echo got $line; exit 3"},
+		Env:     []string{"PS1=$ "},
+	}
+
+	// The first client detaches, the session keeps running
+	conn, err := connect("test", options)
+	assert.NilError(t, err, "Error starting session")
+
+	stdinReader, stdinWriter := io.Pipe()
+	defer stdinWriter.Close()
+
+	stdout := &syncBuffer{}
+	go func() {
+		waitForOutput(stdout, "ready")
+		stdinWriter.Write([]byte{detachPrefix, detachKey})
+	}()
+
+	exitCode, detached, err := attach(conn, &frameWriter{writer: conn}, stdinReader, stdout)
+	assert.NilError(t, err, "Error attaching to session")
+	assert.Equal(t, detached, true, "Client didn't detach")
+	assert.Equal(t, exitCode, 0)
+	conn.Close()
+
+	// The second client receives the scrollback and the exit code
+	conn, err = connect("test", options)
+	assert.NilError(t, err, "Error connecting to running session")
+	defer conn.Close()
+
+	stdinReader, stdinWriter = io.Pipe()
+	defer stdinWriter.Close()
+
+	stdout = &syncBuffer{}
+	go func() {
+		waitForOutput(stdout, "ready")
+		stdinWriter.Write([]byte("world\n"))
+	}()
+
+	exitCode, detached, err = attach(conn, &frameWriter{writer: conn}, stdinReader, stdout)
+	assert.NilError(t, err, "Error attaching to session again")
+	assert.Equal(t, detached, false)
+	assert.Equal(t, exitCode, 3)
+	assert.Assert(t, strings.Contains(stdout.String(), "got world"), "Session output is missing in output %q", stdout.String())
+}
+
+func waitForOutput(output *syncBuffer, expected string) {
+	for i := 0; i < 50; i++ {
+		if strings.Contains(output.String(), expected) {
+			return
+		}
+
+		time.Sleep(time.Millisecond * 100)
+	}
+}
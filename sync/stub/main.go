@@ -3,10 +3,13 @@ package main
 import (
 	"flag"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 
 	"github.com/devspace-cloud/devspace/sync/server"
+	"github.com/devspace-cloud/devspace/sync/session"
+	"github.com/devspace-cloud/devspace/sync/tunnel"
 )
 
 type arrayFlags []string
@@ -22,6 +25,8 @@ func (i *arrayFlags) Set(value string) error {
 
 func printUsage() {
 	fmt.Fprintf(os.Stderr, "Usage: sync [--version] [--upstream] [--downstream] [--exclude] PATH\n")
+	fmt.Fprintf(os.Stderr, "       sync --reverse-forward ADDRESS\n")
+	fmt.Fprintf(os.Stderr, "       sync --session NAME [--detach] [--workdir DIR] [--env KEY=VALUE] [-- COMMAND]\n")
 	os.Exit(1)
 }
 
@@ -30,13 +35,22 @@ var version string
 func main() {
 	var (
 		excludePaths arrayFlags
+		sessionEnv   arrayFlags
 
 		isDownstream = flag.Bool("downstream", false, "Starts the downstream service")
 		isUpstream   = flag.Bool("upstream", false, "Starts the upstream service")
 		showVersion  = flag.Bool("version", false, "Shows the version")
+
+		reverseForward = flag.String("reverse-forward", "", "Listens on the address and tunnels the connections through stdin and stdout")
+
+		sessionName       = flag.String("session", "", "Attaches to the session with the given name and starts it if it does not exist")
+		sessionServerName = flag.String("session-server", "", "Runs the session with the given name (used internally)")
+		sessionDetach     = flag.Bool("detach", false, "Starts the session without attaching to it")
+		sessionWorkDir    = flag.String("workdir", "", "The working directory of the session command")
 	)
 
 	flag.Var(&excludePaths, "exclude", "The exclude paths for downstream watching")
+	flag.Var(&sessionEnv, "env", "Environment variables of the session command in the form KEY=VALUE")
 	flag.Parse()
 
 	// Should we just print the version?
@@ -49,6 +63,61 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Should we tunnel connections back to the local machine?
+	if *reverseForward != "" {
+		listener, err := net.Listen("tcp", *reverseForward)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v", err)
+			os.Exit(1)
+		}
+
+		err = tunnel.Listen(listener, os.Stdin, os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v", err)
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	}
+
+	// Should we start or attach to a session?
+	if *sessionName != "" || *sessionServerName != "" {
+		options := &session.Options{
+			Command: flag.Args(),
+			WorkDir: *sessionWorkDir,
+			Env:     sessionEnv,
+		}
+		if len(options.Command) == 0 {
+			options.Command = []string{"sh", "-c", "command -v bash >/dev/null 2>&1 && exec bash || exec sh"}
+		}
+
+		if *sessionServerName != "" {
+			err := session.Serve(*sessionServerName, options)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v", err)
+				os.Exit(1)
+			}
+
+			os.Exit(0)
+		} else if *sessionDetach {
+			err := session.Start(*sessionName, options)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v", err)
+				os.Exit(1)
+			}
+
+			os.Exit(0)
+		}
+
+		exitCode, err := session.Attach(*sessionName, options)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v", err)
+			os.Exit(1)
+		}
+
+		os.Exit(exitCode)
+	}
+
 	args := flag.Args()
 	if len(args) != 1 {
 		printUsage()
@@ -0,0 +1,230 @@
+package tunnel
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Frame types of the tunnel protocol. Every frame starts with a header that contains the frame type (1 byte),
+// the id of the tunneled connection (4 bytes) and the length of the payload (4 bytes)
+const (
+	frameReady byte = iota + 1
+	frameOpen
+	frameData
+	frameClose
+)
+
+const headerSize = 9
+
+// maxPayloadSize is the maximum size of the payload of a single data frame
+const maxPayloadSize = 32 * 1024
+
+// session multiplexes multiple connections over a single reader and writer (e.g. the stdin and stdout of a process)
+type session struct {
+	reader io.Reader
+
+	writer     io.Writer
+	writeMutex sync.Mutex
+
+	conns      map[uint32]net.Conn
+	connsMutex sync.Mutex
+}
+
+func newSession(reader io.Reader, writer io.Writer) *session {
+	return &session{
+		reader: reader,
+		writer: writer,
+		conns:  map[uint32]net.Conn{},
+	}
+}
+
+// Listen accepts connections on the listener and tunnels them through the reader and writer to the other side of
+// the tunnel, which opens a connection for every accepted connection (see Dial). Listen returns when the reader is closed
+func Listen(listener net.Listener, reader io.Reader, writer io.Writer) error {
+	s := newSession(reader, writer)
+	defer s.closeAll()
+	defer listener.Close()
+
+	err := s.writeFrame(frameReady, 0, nil)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		var nextID uint32
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			nextID++
+			s.add(nextID, conn)
+
+			err = s.writeFrame(frameOpen, nextID, nil)
+			if err != nil {
+				s.remove(nextID)
+				conn.Close()
+				return
+			}
+
+			go s.pipe(nextID, conn)
+		}
+	}()
+
+	return s.readLoop(nil, nil)
+}
+
+// Dial opens a connection with the dial function for every connection the other side of the tunnel accepts (see Listen).
+// The ready channel is closed as soon as the other side listens for connections. Dial returns when the reader is closed
+func Dial(dial func() (net.Conn, error), reader io.Reader, writer io.Writer, readyChan chan struct{}) error {
+	s := newSession(reader, writer)
+	defer s.closeAll()
+
+	return s.readLoop(func(id uint32) {
+		conn, err := dial()
+		if err != nil {
+			s.writeFrame(frameClose, id, nil)
+			return
+		}
+
+		s.add(id, conn)
+		go s.pipe(id, conn)
+	}, readyChan)
+}
+
+// readLoop reads frames until the reader is closed
+func (s *session) readLoop(onOpen func(id uint32), readyChan chan struct{}) error {
+	header := make([]byte, headerSize)
+	for {
+		_, err := io.ReadFull(s.reader, header)
+		if err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil
+			}
+
+			return errors.Wrap(err, "read frame")
+		}
+
+		var (
+			frameType = header[0]
+			id        = binary.BigEndian.Uint32(header[1:5])
+			length    = binary.BigEndian.Uint32(header[5:9])
+		)
+		if length > maxPayloadSize {
+			return errors.Errorf("frame payload of %d bytes exceeds the maximum of %d bytes", length, maxPayloadSize)
+		}
+
+		payload := make([]byte, length)
+		_, err = io.ReadFull(s.reader, payload)
+		if err != nil {
+			return errors.Wrap(err, "read frame payload")
+		}
+
+		switch frameType {
+		case frameReady:
+			if readyChan != nil {
+				close(readyChan)
+				readyChan = nil
+			}
+		case frameOpen:
+			if onOpen != nil {
+				onOpen(id)
+			}
+		case frameData:
+			conn := s.get(id)
+			if conn != nil {
+				_, err = conn.Write(payload)
+				if err != nil {
+					s.closeConn(id, true)
+				}
+			}
+		case frameClose:
+			s.closeConn(id, false)
+		default:
+			return errors.Errorf("unknown frame type %d", frameType)
+		}
+	}
+}
+
+// pipe sends everything that is read from the connection to the other side of the tunnel
+func (s *session) pipe(id uint32, conn net.Conn) {
+	buf := make([]byte, maxPayloadSize)
+	for {
+		n, err := conn.Read(buf)
+		if n > 0 {
+			if s.writeFrame(frameData, id, buf[:n]) != nil {
+				s.closeConn(id, false)
+				return
+			}
+		}
+		if err != nil {
+			s.closeConn(id, true)
+			return
+		}
+	}
+}
+
+func (s *session) writeFrame(frameType byte, id uint32, payload []byte) error {
+	frame := make([]byte, headerSize+len(payload))
+	frame[0] = frameType
+	binary.BigEndian.PutUint32(frame[1:5], id)
+	binary.BigEndian.PutUint32(frame[5:9], uint32(len(payload)))
+	copy(frame[headerSize:], payload)
+
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
+	_, err := s.writer.Write(frame)
+	return err
+}
+
+func (s *session) add(id uint32, conn net.Conn) {
+	s.connsMutex.Lock()
+	defer s.connsMutex.Unlock()
+
+	s.conns[id] = conn
+}
+
+func (s *session) get(id uint32) net.Conn {
+	s.connsMutex.Lock()
+	defer s.connsMutex.Unlock()
+
+	return s.conns[id]
+}
+
+func (s *session) remove(id uint32) net.Conn {
+	s.connsMutex.Lock()
+	defer s.connsMutex.Unlock()
+
+	conn := s.conns[id]
+	delete(s.conns, id)
+	return conn
+}
+
+// closeConn closes the connection with the given id and tells the other side of the tunnel if notify is true
+func (s *session) closeConn(id uint32, notify bool) {
+	conn := s.remove(id)
+	if conn == nil {
+		return
+	}
+
+	conn.Close()
+	if notify {
+		s.writeFrame(frameClose, id, nil)
+	}
+}
+
+func (s *session) closeAll() {
+	s.connsMutex.Lock()
+	defer s.connsMutex.Unlock()
+
+	for id, conn := range s.conns {
+		conn.Close()
+		delete(s.conns, id)
+	}
+}
@@ -0,0 +1,91 @@
+package tunnel
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestTunnel(t *testing.T) {
+	// Start an echo server that is reached through the tunnel
+	echoListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer echoListener.Close()
+
+	go func() {
+		for {
+			conn, err := echoListener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	tunnelListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listenReader, dialWriter := io.Pipe()
+	dialReader, listenWriter := io.Pipe()
+
+	go Listen(tunnelListener, listenReader, listenWriter)
+
+	readyChan := make(chan struct{})
+	dialDone := make(chan error)
+	go func() {
+		dialDone <- Dial(func() (net.Conn, error) {
+			return net.Dial("tcp", echoListener.Addr().String())
+		}, dialReader, dialWriter, readyChan)
+	}()
+
+	select {
+	case <-readyChan:
+	case <-time.After(time.Second * 5):
+		t.Fatal("Timeout waiting for tunnel to be ready")
+	}
+
+	// Multiple connections are tunneled at the same time
+	for _, message := range []string{"hello", "world"} {
+		conn, err := net.Dial("tcp", tunnelListener.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = conn.Write([]byte(message))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		conn.SetReadDeadline(time.Now().Add(time.Second * 5))
+		buf := make([]byte, len(message))
+		_, err = io.ReadFull(conn, buf)
+		if err != nil {
+			t.Fatalf("Error reading from tunnel: %v", err)
+		}
+		if string(buf) != message {
+			t.Fatalf("Expected %s, got %s", message, string(buf))
+		}
+
+		conn.Close()
+	}
+
+	// Closing the stream stops the tunnel
+	listenWriter.Close()
+	select {
+	case err := <-dialDone:
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("Timeout waiting for tunnel to stop")
+	}
+}